@@ -0,0 +1,208 @@
+// Package abi implements Solidity ABI encoding of integer types
+// uint<N> and int<N> on top of uint256.Uint256 without big.Int.
+//
+// Every value occupies one 32-byte word in big-endian byte order.
+// Signed values are represented as two's complement Uint256,
+// e.g. -1 is uint256.Max(), see FromInt64.
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/piliming/bigz/uint256"
+)
+
+// WordSize is the size of ABI word in bytes.
+const WordSize = 32
+
+var (
+	// ErrOutOfRange is returned if value does not fit the type.
+	ErrOutOfRange = errors.New("abi: value out of range")
+
+	// ErrDirtyBits is returned on decoding if the high bits of a word
+	// are not zero (or not sign-extended for signed types).
+	ErrDirtyBits = errors.New("abi: improperly encoded value")
+
+	// ErrShortData is returned on decoding if data is too short.
+	ErrShortData = errors.New("abi: data too short")
+
+	// ErrUnsupportedType is returned if Bits is not one of 8, 16, ..., 256.
+	ErrUnsupportedType = errors.New("abi: unsupported type")
+)
+
+// Type is an ABI integer type: uint<Bits> or int<Bits>.
+type Type struct {
+	Bits   int  // 8, 16, ..., 256
+	Signed bool // int<Bits> if true
+}
+
+// Commonly used types.
+var (
+	Uint8   = Type{Bits: 8}
+	Uint64  = Type{Bits: 64}
+	Uint128 = Type{Bits: 128}
+	Uint256 = Type{Bits: 256}
+	Int8    = Type{Bits: 8, Signed: true}
+	Int64   = Type{Bits: 64, Signed: true}
+	Int128  = Type{Bits: 128, Signed: true}
+	Int256  = Type{Bits: 256, Signed: true}
+)
+
+// ParseType parses Solidity type name like "uint8", "int256" or "uint".
+func ParseType(name string) (Type, error) {
+	var t Type
+	s := name
+	if strings.HasPrefix(s, "int") {
+		t.Signed, s = true, s[3:]
+	} else if strings.HasPrefix(s, "uint") {
+		s = s[4:]
+	} else {
+		return t, fmt.Errorf("%w %q", ErrUnsupportedType, name)
+	}
+
+	if s == "" {
+		t.Bits = 256 // uint is alias for uint256
+		return t, nil
+	}
+	bits, err := strconv.Atoi(s)
+	t.Bits = bits
+	if err != nil || !t.valid() || s[0] == '0' {
+		return Type{}, fmt.Errorf("%w %q", ErrUnsupportedType, name)
+	}
+	return t, nil
+}
+
+// valid reports whether Bits is one of 8, 16, ..., 256.
+func (t Type) valid() bool {
+	return t.Bits >= 8 && t.Bits <= 256 && t.Bits%8 == 0
+}
+
+// String returns Solidity type name, e.g. "uint256".
+func (t Type) String() string {
+	if t.Signed {
+		return "int" + strconv.Itoa(t.Bits)
+	}
+	return "uint" + strconv.Itoa(t.Bits)
+}
+
+// FromInt64 converts signed 64-bit integer to two's complement Uint256.
+func FromInt64(v int64) uint256.Uint256 {
+	if v < 0 {
+		return uint256.Zero().Sub(uint256.From64(uint64(-v))) // -v overflows for MinInt64, still fine
+	}
+	return uint256.From64(uint64(v))
+}
+
+// Check returns ErrOutOfRange based error if u does not fit the type
+// and ErrUnsupportedType based error if the type itself is invalid.
+func (t Type) Check(u uint256.Uint256) error {
+	if !t.valid() {
+		return fmt.Errorf("%w %s", ErrUnsupportedType, t)
+	}
+	if !t.fits(u) {
+		return fmt.Errorf("%w for %s", ErrOutOfRange, t)
+	}
+	return nil
+}
+
+// fits reports whether u is in the range of the valid type.
+// For signed types the upper bits must be the sign extension.
+func (t Type) fits(u uint256.Uint256) bool {
+	if t.Bits >= 256 {
+		return true
+	}
+	if t.Signed {
+		hi := u.Rsh(uint(t.Bits - 1))
+		return hi.IsZero() || hi.Equals(uint256.Max().Rsh(uint(t.Bits-1)))
+	}
+	return u.Rsh(uint(t.Bits)).IsZero()
+}
+
+// Append appends u as 32-byte ABI word to dst.
+// It returns ErrOutOfRange based error if u does not fit the type.
+func (t Type) Append(dst []byte, u uint256.Uint256) ([]byte, error) {
+	if err := t.Check(u); err != nil {
+		return dst, err
+	}
+	b, _ := u.AppendBinary(dst)
+	return b, nil
+}
+
+// AppendPacked appends u in abi.encodePacked style to dst, i.e. using Bits/8
+// bytes without padding. Note, elements of arrays are still padded to 32 bytes
+// in abi.encodePacked, so AppendArray should be used for arrays.
+func (t Type) AppendPacked(dst []byte, u uint256.Uint256) ([]byte, error) {
+	if err := t.Check(u); err != nil {
+		return dst, err
+	}
+	var word [WordSize]byte
+	uint256.StoreBigEndian(word[:], u)
+	return append(dst, word[WordSize-t.Bits/8:]...), nil
+}
+
+// Decode decodes the 32-byte ABI word from the front of data.
+// It validates that the high bits are zero, or sign-extended for signed types.
+func (t Type) Decode(data []byte) (uint256.Uint256, error) {
+	if !t.valid() {
+		return uint256.Zero(), fmt.Errorf("%w %s", ErrUnsupportedType, t)
+	}
+	if len(data) < WordSize {
+		return uint256.Zero(), fmt.Errorf("%w for %s: %d bytes", ErrShortData, t, len(data))
+	}
+	u := uint256.LoadBigEndian(data[:WordSize])
+	if !t.fits(u) {
+		return uint256.Zero(), fmt.Errorf("%w for %s", ErrDirtyBits, t)
+	}
+	return u, nil
+}
+
+// AppendArray appends fixed-size array T[n] to dst: the words of values
+// without length. It is also used for arrays in abi.encodePacked style.
+func (t Type) AppendArray(dst []byte, values []uint256.Uint256) ([]byte, error) {
+	for _, u := range values {
+		var err error
+		if dst, err = t.Append(dst, u); err != nil {
+			return dst, err
+		}
+	}
+	return dst, nil
+}
+
+// AppendSlice appends dynamic array T[] to dst: the length word followed
+// by the words of values. This is the tail part of encoding, the offset
+// in the head part is up to the caller.
+func (t Type) AppendSlice(dst []byte, values []uint256.Uint256) ([]byte, error) {
+	dst, _ = Uint256.Append(dst, uint256.From64(uint64(len(values))))
+	return t.AppendArray(dst, values)
+}
+
+// DecodeArray decodes fixed-size array T[n] from the front of data.
+func (t Type) DecodeArray(data []byte, n int) ([]uint256.Uint256, error) {
+	if n < 0 || n > len(data)/WordSize {
+		return nil, fmt.Errorf("%w for %s[%d]: %d bytes", ErrShortData, t, n, len(data))
+	}
+	values := make([]uint256.Uint256, n)
+	for i := range values {
+		var err error
+		if values[i], err = t.Decode(data[i*WordSize:]); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// DecodeSlice decodes dynamic array T[] from the front of data,
+// which starts with the length word (i.e. data is already at the offset).
+func (t Type) DecodeSlice(data []byte) ([]uint256.Uint256, error) {
+	n, err := Uint64.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	if n.Lo.Lo > uint64(len(data)/WordSize-1) {
+		return nil, fmt.Errorf("%w for %s[]: length %d, %d bytes", ErrShortData, t, n.Lo.Lo, len(data))
+	}
+	return t.DecodeArray(data[WordSize:], int(n.Lo.Lo))
+}
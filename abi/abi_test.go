@@ -0,0 +1,204 @@
+package abi
+
+import (
+	"encoding/hex"
+	"errors"
+	"math"
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/piliming/bigz/uint256"
+)
+
+// word returns hex ABI word with value v padded on the left with pad.
+func word(pad, v string) string {
+	return strings.Repeat(pad, (64-len(v))/len(pad)) + v
+}
+
+// TestParseType unit tests for ParseType function
+func TestParseType(t *testing.T) {
+	good := map[string]Type{
+		"uint":    Uint256,
+		"int":     Int256,
+		"uint8":   Uint8,
+		"int8":    Int8,
+		"uint24":  {Bits: 24},
+		"int128":  Int128,
+		"uint256": Uint256,
+	}
+	for name, expected := range good {
+		if got, err := ParseType(name); err != nil || got != expected {
+			t.Errorf("ParseType(%q) should be %v, got %v, %v", name, expected, got, err)
+		} else if name != "uint" && name != "int" && got.String() != name {
+			t.Errorf("String() should be %q, got %q", name, got)
+		}
+	}
+
+	for _, name := range []string{"", "uint0", "uint7", "uint264", "int08", "uint-8", "bytes32", "uint8[]"} {
+		if _, err := ParseType(name); !errors.Is(err, ErrUnsupportedType) {
+			t.Errorf("ParseType(%q) should fail with unsupported type, got %v", name, err)
+		}
+	}
+}
+
+// TestEncode unit tests for ABI encoding on known vectors
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		typ    Type
+		value  uint256.Uint256
+		word   string
+		packed string
+	}{
+		{Uint8, uint256.From64(0xff), word("0", "ff"), "ff"},
+		{Type{Bits: 16}, uint256.From64(0x1234), word("0", "1234"), "1234"},
+		{Uint256, uint256.Max(), word("f", ""), word("f", "")},
+		{Int8, FromInt64(-1), word("f", ""), "ff"},
+		{Int8, FromInt64(-128), word("f", "80"), "80"},
+		{Int8, FromInt64(127), word("0", "7f"), "7f"},
+		{Int64, FromInt64(math.MinInt64), word("f", "8000000000000000"), "8000000000000000"},
+		{Int256, FromInt64(-2), word("f", "fe"), word("f", "fe")},
+	}
+	for _, tt := range tests {
+		enc, err := tt.typ.Append(nil, tt.value)
+		if err != nil || hex.EncodeToString(enc) != tt.word {
+			t.Errorf("%s.Append(%#x) mismatch:\n\t(-) expected %s\n\t(+)   actual %x, %v", tt.typ, tt.value, tt.word, enc, err)
+		}
+		packed, err := tt.typ.AppendPacked(nil, tt.value)
+		if err != nil || hex.EncodeToString(packed) != tt.packed {
+			t.Errorf("%s.AppendPacked(%#x) mismatch:\n\t(-) expected %s\n\t(+)   actual %x, %v", tt.typ, tt.value, tt.packed, packed, err)
+		}
+		if got, err := tt.typ.Decode(enc); err != nil || !got.Equals(tt.value) {
+			t.Errorf("%s.Decode(%s) mismatch: %#x, %v", tt.typ, tt.word, got, err)
+		}
+	}
+
+	// out of range
+	for _, tt := range []struct {
+		typ   Type
+		value uint256.Uint256
+	}{
+		{Uint8, uint256.From64(256)},
+		{Uint128, uint256.One().Lsh(128)},
+		{Int8, FromInt64(128)},
+		{Int8, FromInt64(-129)},
+		{Int128, uint256.One().Lsh(127)},
+	} {
+		if _, err := tt.typ.Append(nil, tt.value); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("%s.Append(%#x) should fail with out of range, got %v", tt.typ, tt.value, err)
+		}
+		if _, err := tt.typ.AppendPacked(nil, tt.value); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("%s.AppendPacked(%#x) should fail with out of range, got %v", tt.typ, tt.value, err)
+		}
+	}
+
+	// invalid types built without ParseType
+	for _, typ := range []Type{{}, {Bits: 7}, {Bits: 12, Signed: true}, {Bits: 264}, {Bits: -8}} {
+		if _, err := typ.Append(nil, uint256.Zero()); !errors.Is(err, ErrUnsupportedType) {
+			t.Errorf("%s.Append should fail with unsupported type, got %v", typ, err)
+		}
+		if _, err := typ.AppendPacked(nil, uint256.Zero()); !errors.Is(err, ErrUnsupportedType) {
+			t.Errorf("%s.AppendPacked should fail with unsupported type, got %v", typ, err)
+		}
+		if _, err := typ.Decode(make([]byte, WordSize)); !errors.Is(err, ErrUnsupportedType) {
+			t.Errorf("%s.Decode should fail with unsupported type, got %v", typ, err)
+		}
+	}
+}
+
+// TestDecode unit tests for ABI decoding validation
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		typ  Type
+		word string
+		err  error
+	}{
+		{Uint8, word("0", "100"), ErrDirtyBits},
+		{Uint8, "80" + word("0", "")[2:], ErrDirtyBits},
+		{Int8, word("0", "80"), ErrDirtyBits},
+		{Int8, word("f", "7f"), ErrDirtyBits},
+		{Uint256, "00", ErrShortData},
+	}
+	for _, tt := range tests {
+		data, _ := hex.DecodeString(tt.word)
+		if _, err := tt.typ.Decode(data); !errors.Is(err, tt.err) {
+			t.Errorf("%s.Decode(%s) should fail with %v, got %v", tt.typ, tt.word, tt.err, err)
+		}
+	}
+}
+
+// TestArrays unit tests for fixed and dynamic arrays
+func TestArrays(t *testing.T) {
+	values := []uint256.Uint256{uint256.From64(1), uint256.From64(2)}
+
+	enc, err := Uint8.AppendSlice(nil, values)
+	if expected := word("0", "2") + word("0", "1") + word("0", "2"); err != nil || hex.EncodeToString(enc) != expected {
+		t.Fatalf("AppendSlice mismatch:\n\t(-) expected %s\n\t(+)   actual %x, %v", expected, enc, err)
+	}
+	got, err := Uint8.DecodeSlice(enc)
+	if err != nil || len(got) != 2 || !got[0].Equals(values[0]) || !got[1].Equals(values[1]) {
+		t.Fatalf("DecodeSlice mismatch: %v, %v", got, err)
+	}
+
+	arr, err := Uint8.AppendArray(nil, values)
+	if err != nil || hex.EncodeToString(arr) != hex.EncodeToString(enc[WordSize:]) {
+		t.Fatalf("AppendArray mismatch: %x, %v", arr, err)
+	}
+	if got, err := Uint8.DecodeArray(arr, 2); err != nil || len(got) != 2 || !got[1].Equals(values[1]) {
+		t.Fatalf("DecodeArray mismatch: %v, %v", got, err)
+	}
+
+	// errors
+	if _, err := Uint8.AppendArray(nil, []uint256.Uint256{uint256.From64(256)}); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("AppendArray should fail with out of range, got %v", err)
+	}
+	if _, err := Uint8.DecodeArray(arr, 3); !errors.Is(err, ErrShortData) {
+		t.Errorf("DecodeArray should fail with short data, got %v", err)
+	}
+	if _, err := Uint8.DecodeSlice(enc[:len(enc)-1]); !errors.Is(err, ErrShortData) {
+		t.Errorf("DecodeSlice should fail with short data, got %v", err)
+	}
+	huge, _ := Uint256.Append(nil, uint256.Max())
+	if _, err := Uint8.DecodeSlice(huge); err == nil {
+		t.Errorf("DecodeSlice should fail on huge length")
+	}
+	one, _ := Uint256.Append(nil, uint256.One())
+	if _, err := Int8.DecodeSlice(append(one, huge[1:]...)); !errors.Is(err, ErrShortData) {
+		t.Errorf("DecodeSlice should fail with short data, got %v", err)
+	}
+	dirty, _ := hex.DecodeString(word("0", "100"))
+	if _, err := Int8.DecodeSlice(append(one, dirty...)); !errors.Is(err, ErrDirtyBits) {
+		t.Errorf("DecodeSlice should fail with dirty bits, got %v", err)
+	}
+}
+
+// TestRand checks ABI encoding against big.Int on random values
+func TestRand(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		typ := Type{Bits: 8 * (1 + r.Intn(32)), Signed: r.Intn(2) == 0}
+
+		// random value in range
+		x := new(big.Int).Rand(r, new(big.Int).Lsh(big.NewInt(1), uint(typ.Bits)))
+		if typ.Signed {
+			x.Sub(x, new(big.Int).Lsh(big.NewInt(1), uint(typ.Bits-1)))
+		}
+		u := uint256.FromBig(new(big.Int).And(x, uint256.Max().Big())) // two's complement
+
+		enc, err := typ.Append(nil, u)
+		if err != nil {
+			t.Fatalf("%s.Append(%v) failed: %v", typ, x, err)
+		}
+		got, err := typ.Decode(enc)
+		if err != nil || !got.Equals(u) {
+			t.Fatalf("%s.Decode is not the inverse of Append for %v, got %#x, %v", typ, x, got, err)
+		}
+
+		packed, _ := typ.AppendPacked(nil, u)
+		expected := new(big.Int).And(x, new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(typ.Bits)), big.NewInt(1)))
+		if hex.EncodeToString(packed) != hex.EncodeToString(expected.FillBytes(make([]byte, typ.Bits/8))) {
+			t.Fatalf("%s.AppendPacked(%v) mismatch: %x", typ, x, packed)
+		}
+	}
+}
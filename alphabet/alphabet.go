@@ -0,0 +1,115 @@
+// Package alphabet defines digit alphabets for EncodeBase and DecodeBase
+// functions of the width packages, e.g. uint128.EncodeBase.
+//
+// All built-in alphabets are in ASCII order, so fixed-length padded
+// encodings sort lexicographically in the same order as the values.
+package alphabet
+
+import (
+	"errors"
+	"fmt"
+)
+
+// invalid marks bytes which are not digits. Digit values are at most
+// 254, as there are at most 255 digits.
+const invalid = 0xff
+
+// Alphabet is a set of unique digits, the first one is zero.
+// Decoding may also accept aliases, e.g. lower case letters.
+type Alphabet struct {
+	digits string
+	values [256]byte // digit values, invalid if not a digit
+}
+
+// Built-in alphabets.
+var (
+	// Base58 is the Bitcoin base58 alphabet without 0, O, I and l.
+	Base58 = MustNew("123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz")
+
+	// Crockford32 is Douglas Crockford's base32 alphabet. Decoding is case
+	// insensitive and accepts I and L as 1, O as 0.
+	Crockford32 = MustNew("0123456789ABCDEFGHJKMNPQRSTVWXYZ").
+			CaseInsensitive().withAlias("IiLl", '1').withAlias("Oo", '0')
+
+	// Base36 is digits and lower case letters. Decoding is case insensitive.
+	Base36 = MustNew("0123456789abcdefghijklmnopqrstuvwxyz").CaseInsensitive()
+
+	// Base62 is digits, upper and lower case letters.
+	Base62 = MustNew("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz")
+)
+
+// New returns alphabet of the digits, which must be 2 to 255 unique bytes.
+func New(digits string) (*Alphabet, error) {
+	if len(digits) < 2 || len(digits) > invalid {
+		return nil, fmt.Errorf("alphabet: invalid length %d", len(digits))
+	}
+	a := &Alphabet{digits: digits}
+	for i := range a.values {
+		a.values[i] = invalid
+	}
+	for i := 0; i < len(digits); i++ {
+		if a.values[digits[i]] != invalid {
+			return nil, fmt.Errorf("alphabet: duplicate digit %q", digits[i])
+		}
+		a.values[digits[i]] = byte(i)
+	}
+	return a, nil
+}
+
+// MustNew is like New but panics on error.
+func MustNew(digits string) *Alphabet {
+	a, err := New(digits)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// CaseInsensitive returns a copy of the alphabet which also decodes
+// the other case of ASCII letters, unless it is a digit itself.
+func (a *Alphabet) CaseInsensitive() *Alphabet {
+	b := *a
+	for i := 0; i < len(a.digits); i++ {
+		c := a.digits[i]
+		switch {
+		case 'a' <= c && c <= 'z':
+			c -= 'a' - 'A'
+		case 'A' <= c && c <= 'Z':
+			c += 'a' - 'A'
+		default:
+			continue
+		}
+		if b.values[c] == invalid {
+			b.values[c] = a.values[a.digits[i]]
+		}
+	}
+	return &b
+}
+
+// withAlias returns a copy of the alphabet which decodes aliases as digit.
+func (a *Alphabet) withAlias(aliases string, digit byte) *Alphabet {
+	b := *a
+	for i := 0; i < len(aliases); i++ {
+		if b.values[aliases[i]] != invalid {
+			panic(errors.New("alphabet: alias is a digit"))
+		}
+		b.values[aliases[i]] = a.values[digit]
+	}
+	return &b
+}
+
+// Radix returns the number of digits.
+func (a *Alphabet) Radix() int {
+	return len(a.digits)
+}
+
+// String returns the digits.
+func (a *Alphabet) String() string {
+	return a.digits
+}
+
+// Value returns the value of digit c, aliases are accepted too.
+func (a *Alphabet) Value(c byte) (int, bool) {
+	v := a.values[c]
+	return int(v), v != invalid
+}
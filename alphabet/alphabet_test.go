@@ -0,0 +1,88 @@
+package alphabet
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+// TestNew unit tests for New function
+func TestNew(t *testing.T) {
+	for _, digits := range []string{"", "0", "00", "0120", strings.Repeat("x", 256)} {
+		if _, err := New(digits); err == nil {
+			t.Errorf("New(%q) should fail", digits)
+		}
+	}
+
+	a, err := New("01")
+	if err != nil || a.Radix() != 2 || a.String() != "01" {
+		t.Fatalf("New(01) mismatch: %v, %v", a, err)
+	}
+	if v, ok := a.Value('1'); !ok || v != 1 {
+		t.Errorf("Value(1) should be 1, got %d, %v", v, ok)
+	}
+	if _, ok := a.Value('2'); ok {
+		t.Errorf("Value(2) should fail")
+	}
+
+	// the largest alphabet has all bytes but one
+	var all []byte
+	for c := 0; c < 256; c++ {
+		all = append(all, byte(c))
+	}
+	if _, err := New(string(all)); err == nil {
+		t.Errorf("New of 256 digits should fail")
+	}
+	a, err = New(string(all[1:]))
+	if err != nil || a.Radix() != 255 {
+		t.Fatalf("New of 255 digits failed: %v", err)
+	}
+	if v, ok := a.Value(0xff); !ok || v != 254 {
+		t.Errorf("Value(0xff) should be 254, got %d, %v", v, ok)
+	}
+	if _, ok := a.Value(0x00); ok {
+		t.Errorf("Value(0x00) should fail")
+	}
+}
+
+// TestBuiltin unit tests for built-in alphabets
+func TestBuiltin(t *testing.T) {
+	for _, a := range []*Alphabet{Base58, Crockford32, Base36, Base62} {
+		if !sort.SliceIsSorted([]byte(a.String()), func(i, j int) bool { return a.String()[i] < a.String()[j] }) {
+			t.Errorf("%s is not in ASCII order", a)
+		}
+		for i := 0; i < a.Radix(); i++ {
+			if v, ok := a.Value(a.String()[i]); !ok || v != i {
+				t.Errorf("%s: Value(%q) should be %d, got %d, %v", a, a.String()[i], i, v, ok)
+			}
+		}
+	}
+
+	if Base58.Radix() != 58 || Crockford32.Radix() != 32 || Base36.Radix() != 36 || Base62.Radix() != 62 {
+		t.Fatalf("wrong radix")
+	}
+	for _, c := range "0OIl" {
+		if _, ok := Base58.Value(byte(c)); ok {
+			t.Errorf("Base58: %q should be invalid", c)
+		}
+	}
+
+	aliases := map[byte]int{'a': 10, 'z': 31, 'i': 1, 'I': 1, 'l': 1, 'L': 1, 'o': 0, 'O': 0}
+	for c, expected := range aliases {
+		if v, ok := Crockford32.Value(c); !ok || v != expected {
+			t.Errorf("Crockford32: Value(%q) should be %d, got %d, %v", c, expected, v, ok)
+		}
+	}
+	for _, c := range "Uu" {
+		if _, ok := Crockford32.Value(byte(c)); ok {
+			t.Errorf("Crockford32: %q should be invalid", c)
+		}
+	}
+
+	if v, ok := Base36.Value('Z'); !ok || v != 35 {
+		t.Errorf("Base36: Value(Z) should be 35, got %d, %v", v, ok)
+	}
+	if v, ok := Base62.Value('Z'); !ok || v != 35 {
+		t.Errorf("Base62: Value(Z) should be 35, got %d, %v", v, ok)
+	}
+}
@@ -0,0 +1,76 @@
+package bigz
+
+import (
+	u1024 "github.com/piliming/bigz/uint1024"
+	u128 "github.com/piliming/bigz/uint128"
+	u256 "github.com/piliming/bigz/uint256"
+	u512 "github.com/piliming/bigz/uint512"
+)
+
+// Integer is a constraint that permits uint64 and any fixed-width
+// unsigned integer type. It is used by the width conversion functions.
+type Integer interface {
+	uint64 | Uint128 | Uint256 | Uint512 | Uint1024
+}
+
+// To128 converts any unsigned integer to a Uint128 value.
+// Provides ok successful flag as a second return value.
+// If input overflows 128-bit then ok=false and
+// the lower 128 bits are returned, just like Go conversion does.
+func To128[T Integer](v T) (Uint128, bool) {
+	w, ok := toWords(v, 2)
+	return u128.FromWords([2]uint64(w[:2])), ok
+}
+
+// To256 converts any unsigned integer to a Uint256 value.
+// Provides ok successful flag as a second return value.
+// If input overflows 256-bit then ok=false and
+// the lower 256 bits are returned, just like Go conversion does.
+func To256[T Integer](v T) (Uint256, bool) {
+	w, ok := toWords(v, 4)
+	return u256.FromWords([4]uint64(w[:4])), ok
+}
+
+// To512 converts any unsigned integer to a Uint512 value.
+// Provides ok successful flag as a second return value.
+// If input overflows 512-bit then ok=false and
+// the lower 512 bits are returned, just like Go conversion does.
+func To512[T Integer](v T) (Uint512, bool) {
+	w, ok := toWords(v, 8)
+	return u512.FromWords([8]uint64(w[:8])), ok
+}
+
+// To1024 converts any unsigned integer to a Uint1024 value.
+// It never fails since Uint1024 is the widest type,
+// ok flag is provided for consistency with other conversions.
+func To1024[T Integer](v T) (Uint1024, bool) {
+	w, ok := toWords(v, 16)
+	return u1024.FromWords(w), ok
+}
+
+// toWords returns 64-bit limbs of v in little-endian order.
+// The ok flag is false if any of limbs starting from n is non-zero.
+func toWords[T Integer](v T, n int) (w [16]uint64, ok bool) {
+	switch x := any(v).(type) {
+	case uint64:
+		w[0] = x
+	case Uint128:
+		t := x.Words()
+		copy(w[:], t[:])
+	case Uint256:
+		t := x.Words()
+		copy(w[:], t[:])
+	case Uint512:
+		t := x.Words()
+		copy(w[:], t[:])
+	case Uint1024:
+		w = x.Words()
+	}
+
+	for _, x := range w[n:] {
+		if x != 0 {
+			return w, false
+		}
+	}
+	return w, true
+}
@@ -0,0 +1,232 @@
+// Package evm implements the arithmetic, comparison and bitwise shift
+// opcodes of the Ethereum Virtual Machine on uint256.Uint256.
+//
+// All words are unsigned 256-bit values, signed opcodes interpret them
+// as two's complement. The semantics follow the Yellow Paper exactly:
+// division and modulo by zero give zero, comparisons give 1 or 0.
+// Operands are in stack order, i.e. the first argument is the top of stack.
+package evm
+
+import (
+	"github.com/piliming/bigz/uint256"
+)
+
+// Uint256 is an alias for uint256.Uint256.
+type Uint256 = uint256.Uint256
+
+// signBit is the sign bit of the most significant word.
+const signBit = 1 << 63
+
+// isNeg reports whether x is negative in two's complement.
+func isNeg(x Uint256) bool {
+	return x.Hi.Hi&signBit != 0
+}
+
+// neg returns two's complement negation of x.
+func neg(x Uint256) Uint256 {
+	return uint256.Zero().Sub(x)
+}
+
+// abs returns the absolute value of x, MinInt256 stays 2**255.
+func abs(x Uint256) Uint256 {
+	if isNeg(x) {
+		return neg(x)
+	}
+	return x
+}
+
+// bool2word returns 1 for true and 0 for false.
+func bool2word(b bool) Uint256 {
+	if b {
+		return uint256.One()
+	}
+	return uint256.Zero()
+}
+
+// shiftAmount returns the shift amount if it is less than 256.
+func shiftAmount(n Uint256) (uint, bool) {
+	if !n.Hi.IsZero() || n.Lo.Hi != 0 || n.Lo.Lo >= 256 {
+		return 0, false
+	}
+	return uint(n.Lo.Lo), true
+}
+
+// Add returns x + y (ADD, 0x01).
+func Add(x, y Uint256) Uint256 {
+	return x.Add(y)
+}
+
+// Mul returns x * y (MUL, 0x02).
+func Mul(x, y Uint256) Uint256 {
+	return x.Mul(y)
+}
+
+// Sub returns x - y (SUB, 0x03).
+func Sub(x, y Uint256) Uint256 {
+	return x.Sub(y)
+}
+
+// Div returns unsigned x / y, or zero if y is zero (DIV, 0x04).
+func Div(x, y Uint256) Uint256 {
+	if y.IsZero() {
+		return uint256.Zero()
+	}
+	return x.Div(y)
+}
+
+// SDiv returns signed x / y rounded towards zero, or zero if y is zero
+// (SDIV, 0x05). Note, -2**255 / -1 overflows to -2**255.
+func SDiv(x, y Uint256) Uint256 {
+	if y.IsZero() {
+		return uint256.Zero()
+	}
+	q := abs(x).Div(abs(y))
+	if isNeg(x) != isNeg(y) {
+		return neg(q)
+	}
+	return q
+}
+
+// Mod returns unsigned x % y, or zero if y is zero (MOD, 0x06).
+func Mod(x, y Uint256) Uint256 {
+	if y.IsZero() {
+		return uint256.Zero()
+	}
+	return x.Mod(y)
+}
+
+// SMod returns signed x % y with the sign of x, or zero if y is zero (SMOD, 0x07).
+func SMod(x, y Uint256) Uint256 {
+	if y.IsZero() {
+		return uint256.Zero()
+	}
+	r := abs(x).Mod(abs(y))
+	if isNeg(x) {
+		return neg(r)
+	}
+	return r
+}
+
+// AddMod returns (x + y) % m computed without 256-bit overflow,
+// or zero if m is zero (ADDMOD, 0x08).
+func AddMod(x, y, m Uint256) Uint256 {
+	if m.IsZero() {
+		return uint256.Zero()
+	}
+	lo, carry := uint256.Add(x, y, 0)
+	return mod512(uint256.From64(carry), lo, m)
+}
+
+// MulMod returns (x * y) % m computed with 512-bit intermediate product,
+// or zero if m is zero (MULMOD, 0x09).
+func MulMod(x, y, m Uint256) Uint256 {
+	if m.IsZero() {
+		return uint256.Zero()
+	}
+	hi, lo := uint256.Mul(x, y)
+	return mod512(hi, lo, m)
+}
+
+// mod512 returns (hi, lo) % m for non-zero m.
+func mod512(hi, lo, m Uint256) Uint256 {
+	if hi.IsZero() {
+		return lo.Mod(m)
+	}
+	_, r := uint256.Div(hi.Mod(m), lo, m) // hi%m < m as required by Div
+	return r
+}
+
+// Exp returns x ** y modulo 2**256 (EXP, 0x0A).
+func Exp(x, y Uint256) Uint256 {
+	z := uint256.One()
+	for n := y.BitLen() - 1; n >= 0; n-- {
+		z = z.Mul(z)
+		if y.Bit(n) {
+			z = z.Mul(x)
+		}
+	}
+	return z
+}
+
+// SignExtend extends the sign of the (b+1)-byte two's complement
+// integer x, x is returned as is if b >= 31 (SIGNEXTEND, 0x0B).
+func SignExtend(b, x Uint256) Uint256 {
+	n, ok := shiftAmount(b)
+	if !ok || n >= 31 {
+		return x
+	}
+	bit := 8*n + 7 // sign bit
+	mask := uint256.Max().Rsh(255 - bit)
+	if x.Bit(int(bit)) {
+		return x.Or(mask.Not())
+	}
+	return x.And(mask)
+}
+
+// Lt returns 1 if x < y unsigned, otherwise 0 (LT, 0x10).
+func Lt(x, y Uint256) Uint256 {
+	return bool2word(x.Cmp(y) < 0)
+}
+
+// Gt returns 1 if x > y unsigned, otherwise 0 (GT, 0x11).
+func Gt(x, y Uint256) Uint256 {
+	return bool2word(x.Cmp(y) > 0)
+}
+
+// Slt returns 1 if x < y signed, otherwise 0 (SLT, 0x12).
+func Slt(x, y Uint256) Uint256 {
+	if nx, ny := isNeg(x), isNeg(y); nx != ny {
+		return bool2word(nx)
+	}
+	return bool2word(x.Cmp(y) < 0)
+}
+
+// Sgt returns 1 if x > y signed, otherwise 0 (SGT, 0x13).
+func Sgt(x, y Uint256) Uint256 {
+	return Slt(y, x)
+}
+
+// IsZero returns 1 if x is zero, otherwise 0 (ISZERO, 0x15).
+func IsZero(x Uint256) Uint256 {
+	return bool2word(x.IsZero())
+}
+
+// Byte returns i-th byte of x counting from the most significant one,
+// or zero if i >= 32 (BYTE, 0x1A).
+func Byte(i, x Uint256) Uint256 {
+	n, ok := shiftAmount(i)
+	if !ok || n >= 32 {
+		return uint256.Zero()
+	}
+	return uint256.From64(x.Rsh(248-8*n).Lo.Lo & 0xff)
+}
+
+// Shl returns x << shift, or zero if shift >= 256 (SHL, 0x1B).
+func Shl(shift, x Uint256) Uint256 {
+	n, ok := shiftAmount(shift)
+	if !ok {
+		return uint256.Zero()
+	}
+	return x.Lsh(n)
+}
+
+// Shr returns logical x >> shift, or zero if shift >= 256 (SHR, 0x1C).
+func Shr(shift, x Uint256) Uint256 {
+	n, ok := shiftAmount(shift)
+	if !ok {
+		return uint256.Zero()
+	}
+	return x.Rsh(n)
+}
+
+// Sar returns arithmetic x >> shift filling with the sign bit (SAR, 0x1D).
+func Sar(shift, x Uint256) Uint256 {
+	if !isNeg(x) {
+		return Shr(shift, x)
+	}
+	n, ok := shiftAmount(shift)
+	if !ok {
+		return uint256.Max()
+	}
+	return x.Rsh(n).Or(uint256.Max().Rsh(n).Not())
+}
@@ -0,0 +1,248 @@
+package evm
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/piliming/bigz/uint256"
+)
+
+// testcase is the test vector of go-ethereum's core/vm/testdata, see
+// testdata/README.md. X is pushed first, so Y is the first operand.
+type testcase struct {
+	X        string
+	Y        string
+	Expected string
+}
+
+var (
+	two256 = new(big.Int).Lsh(big.NewInt(1), 256)
+	two255 = new(big.Int).Lsh(big.NewInt(1), 255)
+)
+
+// toS converts two's complement word to signed big.Int.
+func toS(x *big.Int) *big.Int {
+	if x.Cmp(two255) >= 0 {
+		return new(big.Int).Sub(x, two256)
+	}
+	return x
+}
+
+// toU converts big.Int to the word modulo 2**256.
+func toU(x *big.Int) *big.Int {
+	return new(big.Int).Mod(x, two256)
+}
+
+// b2i converts bool to 1 or 0.
+func b2i(b bool) *big.Int {
+	if b {
+		return big.NewInt(1)
+	}
+	return big.NewInt(0)
+}
+
+// opcode is the opcode under test with big.Int reference implementation.
+type opcode struct {
+	name  string
+	arity int
+	fn    func(args ...Uint256) Uint256
+	ref   func(args ...*big.Int) *big.Int
+}
+
+var opcodes = []opcode{
+	{"add", 2, func(a ...Uint256) Uint256 { return Add(a[0], a[1]) },
+		func(a ...*big.Int) *big.Int { return toU(new(big.Int).Add(a[0], a[1])) }},
+	{"mul", 2, func(a ...Uint256) Uint256 { return Mul(a[0], a[1]) },
+		func(a ...*big.Int) *big.Int { return toU(new(big.Int).Mul(a[0], a[1])) }},
+	{"sub", 2, func(a ...Uint256) Uint256 { return Sub(a[0], a[1]) },
+		func(a ...*big.Int) *big.Int { return toU(new(big.Int).Sub(a[0], a[1])) }},
+	{"div", 2, func(a ...Uint256) Uint256 { return Div(a[0], a[1]) },
+		func(a ...*big.Int) *big.Int {
+			if a[1].Sign() == 0 {
+				return new(big.Int)
+			}
+			return new(big.Int).Quo(a[0], a[1])
+		}},
+	{"sdiv", 2, func(a ...Uint256) Uint256 { return SDiv(a[0], a[1]) },
+		func(a ...*big.Int) *big.Int {
+			if a[1].Sign() == 0 {
+				return new(big.Int)
+			}
+			return toU(new(big.Int).Quo(toS(a[0]), toS(a[1])))
+		}},
+	{"mod", 2, func(a ...Uint256) Uint256 { return Mod(a[0], a[1]) },
+		func(a ...*big.Int) *big.Int {
+			if a[1].Sign() == 0 {
+				return new(big.Int)
+			}
+			return new(big.Int).Rem(a[0], a[1])
+		}},
+	{"smod", 2, func(a ...Uint256) Uint256 { return SMod(a[0], a[1]) },
+		func(a ...*big.Int) *big.Int {
+			if a[1].Sign() == 0 {
+				return new(big.Int)
+			}
+			return toU(new(big.Int).Rem(toS(a[0]), toS(a[1])))
+		}},
+	{"addmod", 3, func(a ...Uint256) Uint256 { return AddMod(a[0], a[1], a[2]) },
+		func(a ...*big.Int) *big.Int {
+			if a[2].Sign() == 0 {
+				return new(big.Int)
+			}
+			return new(big.Int).Mod(new(big.Int).Add(a[0], a[1]), a[2])
+		}},
+	{"mulmod", 3, func(a ...Uint256) Uint256 { return MulMod(a[0], a[1], a[2]) },
+		func(a ...*big.Int) *big.Int {
+			if a[2].Sign() == 0 {
+				return new(big.Int)
+			}
+			return new(big.Int).Mod(new(big.Int).Mul(a[0], a[1]), a[2])
+		}},
+	{"exp", 2, func(a ...Uint256) Uint256 { return Exp(a[0], a[1]) },
+		func(a ...*big.Int) *big.Int { return new(big.Int).Exp(a[0], a[1], two256) }},
+	{"signext", 2, func(a ...Uint256) Uint256 { return SignExtend(a[0], a[1]) },
+		func(a ...*big.Int) *big.Int {
+			if a[0].Cmp(big.NewInt(31)) >= 0 {
+				return a[1]
+			}
+			bits := uint(8*a[0].Uint64() + 8)
+			low := new(big.Int).Mod(a[1], new(big.Int).Lsh(big.NewInt(1), bits))
+			if low.Bit(int(bits-1)) == 1 {
+				low.Sub(low, new(big.Int).Lsh(big.NewInt(1), bits))
+			}
+			return toU(low)
+		}},
+	{"lt", 2, func(a ...Uint256) Uint256 { return Lt(a[0], a[1]) },
+		func(a ...*big.Int) *big.Int { return b2i(a[0].Cmp(a[1]) < 0) }},
+	{"gt", 2, func(a ...Uint256) Uint256 { return Gt(a[0], a[1]) },
+		func(a ...*big.Int) *big.Int { return b2i(a[0].Cmp(a[1]) > 0) }},
+	{"slt", 2, func(a ...Uint256) Uint256 { return Slt(a[0], a[1]) },
+		func(a ...*big.Int) *big.Int { return b2i(toS(a[0]).Cmp(toS(a[1])) < 0) }},
+	{"sgt", 2, func(a ...Uint256) Uint256 { return Sgt(a[0], a[1]) },
+		func(a ...*big.Int) *big.Int { return b2i(toS(a[0]).Cmp(toS(a[1])) > 0) }},
+	{"iszero", 1, func(a ...Uint256) Uint256 { return IsZero(a[0]) },
+		func(a ...*big.Int) *big.Int { return b2i(a[0].Sign() == 0) }},
+	{"byte", 2, func(a ...Uint256) Uint256 { return Byte(a[0], a[1]) },
+		func(a ...*big.Int) *big.Int {
+			if a[0].Cmp(big.NewInt(32)) >= 0 {
+				return new(big.Int)
+			}
+			return big.NewInt(int64(a[1].FillBytes(make([]byte, 32))[a[0].Uint64()]))
+		}},
+	{"shl", 2, func(a ...Uint256) Uint256 { return Shl(a[0], a[1]) },
+		func(a ...*big.Int) *big.Int {
+			if a[0].Cmp(big.NewInt(256)) >= 0 {
+				return new(big.Int)
+			}
+			return toU(new(big.Int).Lsh(a[1], uint(a[0].Uint64())))
+		}},
+	{"shr", 2, func(a ...Uint256) Uint256 { return Shr(a[0], a[1]) },
+		func(a ...*big.Int) *big.Int {
+			if a[0].Cmp(big.NewInt(256)) >= 0 {
+				return new(big.Int)
+			}
+			return new(big.Int).Rsh(a[1], uint(a[0].Uint64()))
+		}},
+	{"sar", 2, func(a ...Uint256) Uint256 { return Sar(a[0], a[1]) },
+		func(a ...*big.Int) *big.Int {
+			n := uint(256)
+			if a[0].Cmp(big.NewInt(256)) < 0 {
+				n = uint(a[0].Uint64())
+			}
+			return toU(new(big.Int).Rsh(toS(a[1]), n)) // big.Int Rsh rounds towards -inf
+		}},
+}
+
+// word parses 64 hex digits.
+func word(t *testing.T, s string) *big.Int {
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != 32 {
+		t.Fatalf("bad test vector word %q", s)
+	}
+	return new(big.Int).SetBytes(b)
+}
+
+// hex64 formats the word as 64 hex digits.
+func hex64(x *big.Int) string {
+	return fmt.Sprintf("%064x", x)
+}
+
+// TestVectors checks binary opcodes against go-ethereum's test vectors.
+func TestVectors(t *testing.T) {
+	for _, op := range opcodes {
+		if op.arity != 2 {
+			continue // no vectors, see testdata/README.md
+		}
+		op := op
+		t.Run(op.name, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", "testcases_"+op.name+".json"))
+			if err != nil {
+				t.Fatalf("failed to read test vectors: %v", err)
+			}
+			var cases []testcase
+			if err := json.Unmarshal(data, &cases); err != nil {
+				t.Fatalf("failed to parse test vectors: %v", err)
+			}
+			if len(cases) == 0 {
+				t.Fatalf("no test vectors")
+			}
+
+			for _, tc := range cases {
+				x, y := uint256.FromBig(word(t, tc.X)), uint256.FromBig(word(t, tc.Y))
+				if got := hex64(op.fn(y, x).Big()); got != tc.Expected {
+					t.Errorf("%s(%s, %s) mismatch:\n\t(-) expected %s\n\t(+)   actual %s", op.name, tc.Y, tc.X, tc.Expected, got)
+				}
+			}
+		})
+	}
+}
+
+// TestAddModVector checks the case of go-ethereum's TestAddMod
+// (core/vm/instructions_test.go), where x + y overflows 256 bits.
+// There are no ADDMOD, MULMOD or ISZERO files in core/vm/testdata.
+func TestAddModVector(t *testing.T) {
+	x := uint256.FromBig(word(t, "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+	y := uint256.FromBig(word(t, "fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffe"))
+	m := uint256.FromBig(word(t, "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+	expected := "fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffe"
+	if got := hex64(AddMod(x, y, m).Big()); got != expected {
+		t.Errorf("addmod mismatch:\n\t(-) expected %s\n\t(+)   actual %s", expected, got)
+	}
+}
+
+// randWord returns random word biased to edge cases.
+func randWord(r *rand.Rand) *big.Int {
+	switch r.Intn(4) {
+	case 0:
+		return big.NewInt(r.Int63n(300)) // small, incl. shift amounts and byte indexes
+	case 1:
+		return toU(big.NewInt(-r.Int63n(300))) // small negative
+	case 2:
+		return new(big.Int).Rand(r, new(big.Int).Lsh(big.NewInt(1), uint(r.Intn(257))))
+	}
+	return new(big.Int).Rand(r, two256)
+}
+
+// TestRand checks all opcodes against big.Int reference on random values.
+func TestRand(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, op := range opcodes {
+		for i := 0; i < 10000; i++ {
+			in := make([]*big.Int, op.arity)
+			args := make([]Uint256, op.arity)
+			for k := range in {
+				in[k] = randWord(r)
+				args[k] = uint256.FromBig(in[k])
+			}
+			if expected, got := hex64(op.ref(in...)), hex64(op.fn(args...).Big()); got != expected {
+				t.Fatalf("%s(%x) mismatch:\n\t(-) expected %s\n\t(+)   actual %s", op.name, in, expected, got)
+			}
+		}
+	}
+}
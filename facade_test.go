@@ -1,6 +1,8 @@
 package bigz_test
 
 import (
+	"fmt"
+	"math/big"
 	"testing"
 
 	"github.com/piliming/bigz"
@@ -34,3 +36,88 @@ func TestUint256(t *testing.T) {
 		t.Errorf("Max256 failed: %v", got)
 	}
 }
+
+// TestUint512 dummy tests for Uint512 helpers.
+func TestUint512(t *testing.T) {
+	if got := bigz.Zero512().String(); got != "0" {
+		t.Errorf("Zero512 failed: %v", got)
+	}
+	if got := bigz.One512().String(); got != "1" {
+		t.Errorf("One512 failed: %v", got)
+	}
+	if got := bigz.Max512().Add(bigz.One512()); !got.IsZero() {
+		t.Errorf("Max512 failed: %v", got)
+	}
+}
+
+// TestUint1024 dummy tests for Uint1024 helpers.
+func TestUint1024(t *testing.T) {
+	if got := bigz.Zero1024().String(); got != "0" {
+		t.Errorf("Zero1024 failed: %v", got)
+	}
+	if got := bigz.One1024().String(); got != "1" {
+		t.Errorf("One1024 failed: %v", got)
+	}
+	if got := bigz.Max1024().Add(bigz.One1024()); !got.IsZero() {
+		t.Errorf("Max1024 failed: %v", got)
+	}
+}
+
+// TestConstructors tests From64/FromBig/Parse helpers of all widths.
+func TestConstructors(t *testing.T) {
+	i := big.NewInt(12345)
+	for _, got := range []fmt.Stringer{
+		bigz.From64To128(12345), bigz.FromBig128(i), first(bigz.FromBigEx128(i)), first(bigz.Parse128("12345")),
+		bigz.From64To256(12345), bigz.FromBig256(i), first(bigz.FromBigEx256(i)), first(bigz.Parse256("12345")),
+		bigz.From64To512(12345), bigz.FromBig512(i), first(bigz.FromBigEx512(i)), first(bigz.Parse512("12345")),
+		bigz.From64To1024(12345), bigz.FromBig1024(i), first(bigz.FromBigEx1024(i)), first(bigz.Parse1024("12345")),
+	} {
+		if got.String() != "12345" {
+			t.Errorf("%T constructor failed: %v", got, got)
+		}
+	}
+
+	if _, err := bigz.Parse128("340282366920938463463374607431768211456"); err == nil {
+		t.Errorf("Parse128 should fail on overflow")
+	}
+}
+
+// TestConvert tests conversions between widths.
+func TestConvert(t *testing.T) {
+	// widening never fails
+	if got, ok := bigz.To1024(bigz.Max128()); !ok || got.String() != bigz.Max128().String() {
+		t.Errorf("To1024(Max128) failed: %v %v", got, ok)
+	}
+	if got, ok := bigz.To512(bigz.Max256()); !ok || got.String() != bigz.Max256().String() {
+		t.Errorf("To512(Max256) failed: %v %v", got, ok)
+	}
+	if got, ok := bigz.To256(uint64(42)); !ok || got.String() != "42" {
+		t.Errorf("To256(42) failed: %v %v", got, ok)
+	}
+
+	// narrowing
+	if got, ok := bigz.To128(bigz.From64To1024(42)); !ok || got.String() != "42" {
+		t.Errorf("To128(1024-bit 42) failed: %v %v", got, ok)
+	}
+	if got, ok := bigz.To256(bigz.Max512().Rsh(256)); !ok || !got.Equals(bigz.Max256()) {
+		t.Errorf("To256(2^256-1) failed: %v %v", got, ok)
+	}
+	if got, ok := bigz.To256(bigz.Max512().Rsh(255)); ok || !got.Equals(bigz.Max256()) {
+		t.Errorf("To256(2^257-1) should overflow: %v %v", got, ok)
+	}
+	if got, ok := bigz.To128(bigz.One1024().Lsh(1000).Add(bigz.From64To1024(7))); ok || got.String() != "7" {
+		t.Errorf("To128(2^1000+7) should overflow: %v %v", got, ok)
+	}
+
+	// round trip
+	x := bigz.Max1024().Rsh(600)
+	y, _ := bigz.To512(x)
+	if z, ok := bigz.To1024(y); !ok || !z.Equals(x) {
+		t.Errorf("To1024(To512(%v)) failed: %v %v", x, z, ok)
+	}
+}
+
+// first returns first of two values.
+func first[T any](v T, _ any) T {
+	return v
+}
@@ -0,0 +1,56 @@
+package nat
+
+import "math"
+
+// AlphabetLen returns the number of digits of the maximum bitCount-bit
+// value in base, i.e. the length of fixed-size encoding.
+func AlphabetLen(bitCount, base int) int {
+	return int(math.Ceil(float64(bitCount) / math.Log2(float64(base))))
+}
+
+// AppendAlphabet appends x in base len(digits) using digits to dst,
+// padded with the zero digit to width. The base must be in range [2, 255].
+func AppendAlphabet(dst []byte, x []uint64, digits string, width int) []byte {
+	var buf [maxDigits]byte
+	i := putDigitsDiv(buf[:], norm(x), len(digits), digits)
+	if i == len(buf) && width < 1 {
+		width = 1 // zero is a single zero digit
+	}
+	for n := len(buf) - i; n < width; n++ {
+		dst = append(dst, digits[0])
+	}
+	return append(dst, buf[i:]...)
+}
+
+// ParseAlphabet interprets s in base using value function, which returns
+// the digit value of c or false if c is not a valid digit, and stores the
+// result in z. Empty s, invalid digits and s longer than the fixed-size
+// encoding of z are strconv.ErrSyntax errors, overflow is strconv.ErrRange
+// error. Function name of errors is fn.
+func ParseAlphabet[S ~string | ~[]byte](fn string, z []uint64, s S, base int, value func(c byte) (int, bool)) error {
+	for i := range z {
+		z[i] = 0
+	}
+	if len(s) == 0 || len(s) > AlphabetLen(64*len(z), base) {
+		return syntaxError(fn, s)
+	}
+
+	bb := bigBases[base]
+	n := 0 // words in use
+	for i := 0; i < len(s); {
+		var acc, m uint64 = 0, 1
+		for k := 0; k < bb.n && i < len(s); k, i = k+1, i+1 {
+			d, ok := value(s[i])
+			if !ok || d >= base {
+				return syntaxError(fn, s)
+			}
+			acc = acc*uint64(base) + uint64(d)
+			m *= uint64(base)
+		}
+		var ok bool
+		if n, ok = mulAddWord(z, n, m, acc); !ok {
+			return rangeError(fn, z, s)
+		}
+	}
+	return nil
+}
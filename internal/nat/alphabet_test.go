@@ -0,0 +1,69 @@
+package nat
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestAlphabetLen checks the fixed-size encoding length on all bases.
+func TestAlphabetLen(t *testing.T) {
+	digits := strings.Repeat("x", 255)
+	for _, n := range []int{2, 4, 8, 16} {
+		max := make([]uint64, n)
+		for i := range max {
+			max[i] = ^uint64(0)
+		}
+		for base := 2; base < 256; base++ {
+			expected := len(AppendAlphabet(nil, max, digits[:base], 0))
+			if got := AlphabetLen(64*n, base); got != expected {
+				t.Fatalf("AlphabetLen(%d, %d) mismatch: expected %d, got %d", 64*n, base, expected, got)
+			}
+		}
+	}
+}
+
+// TestAlphabet checks custom alphabet encoding against big.Int.
+func TestAlphabet(t *testing.T) {
+	value := func(c byte) (int, bool) {
+		v := strings.IndexByte(lowerDigits, c)
+		return v, v >= 0
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		x := randWords(r)
+		base := 2 + r.Intn(35)
+		digits := lowerDigits[:base]
+
+		expected := toBig(x).Text(base)
+		if got := string(AppendAlphabet(nil, x, digits, 0)); got != expected {
+			t.Fatalf("AppendAlphabet(%#x, %d) mismatch:\n\t(-) expected %s\n\t(+)   actual %s", x, base, expected, got)
+		}
+		padded := string(AppendAlphabet(nil, x, digits, AlphabetLen(64*len(x), base)))
+		if len(padded) != AlphabetLen(64*len(x), base) || strings.TrimLeft(padded, "0") != strings.TrimLeft(expected, "0") {
+			t.Fatalf("AppendAlphabet(%#x, %d) padded mismatch: %s", x, base, padded)
+		}
+
+		z := make([]uint64, len(x))
+		for _, s := range []string{expected, padded} {
+			if err := ParseAlphabet("test", z, s, base, value); err != nil || toBig(z).Cmp(toBig(x)) != 0 {
+				t.Fatalf("ParseAlphabet(%s, %d) mismatch: %#x, %v", s, base, z, err)
+			}
+		}
+		if n := len(norm(x)); n > 0 && n < len(x) {
+			if err := ParseAlphabet("test", z[:n-1], expected, base, value); err == nil {
+				t.Fatalf("ParseAlphabet(%s, %d) into fewer words should fail", expected, base)
+			}
+		}
+	}
+
+	z := make([]uint64, 2)
+	for _, s := range []string{"", "-1", "12z", "1" + strings.Repeat("0", 128), "2" + strings.Repeat("0", 127)} {
+		err := ParseAlphabet("test", z, s, 2, value)
+		if e, ok := err.(*strconv.NumError); !ok || e.Func != "test" || e.Num != s {
+			t.Errorf("ParseAlphabet(%q) should fail with NumError, got %v", s, err)
+		}
+	}
+}
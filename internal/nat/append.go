@@ -0,0 +1,22 @@
+package nat
+
+import "encoding/binary"
+
+// AppendHexPadded appends exactly 16 lower case hex digits per word of x to dst.
+func AppendHexPadded(dst []byte, x []uint64) []byte {
+	for i := len(x) - 1; i >= 0; i-- {
+		w := x[i]
+		for s := 60; s >= 0; s -= 4 {
+			dst = append(dst, lowerDigits[w>>uint(s)&0xf])
+		}
+	}
+	return dst
+}
+
+// AppendBigEndian appends 8 bytes per word of x to dst in big-endian byte order.
+func AppendBigEndian(dst []byte, x []uint64) []byte {
+	for i := len(x) - 1; i >= 0; i-- {
+		dst = binary.BigEndian.AppendUint64(dst, x[i])
+	}
+	return dst
+}
@@ -0,0 +1,151 @@
+package nat
+
+import "math/bits"
+
+// mulVV sets z = x*y, len(z) must be at least len(x)+len(y).
+func mulVV(z, x, y []uint64) {
+	for i := range z {
+		z[i] = 0
+	}
+	for j, yj := range y {
+		if yj == 0 {
+			continue
+		}
+		var carry uint64
+		for i, xi := range x {
+			hi, lo := bits.Mul64(xi, yj)
+			var c uint64
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			z[i+j], c = bits.Add64(z[i+j], lo, 0)
+			carry = hi + c
+		}
+		z[j+len(x)] = carry
+	}
+}
+
+// addVV sets z = z + x and returns carry, len(z) must be at least len(x).
+func addVV(z, x []uint64) uint64 {
+	var c uint64
+	for i, xi := range x {
+		z[i], c = bits.Add64(z[i], xi, c)
+	}
+	for i := len(x); i < len(z) && c != 0; i++ {
+		z[i], c = bits.Add64(z[i], 0, c)
+	}
+	return c
+}
+
+// mulSubVW sets z = z - x*y over len(x)+1 words of z and returns borrow.
+func mulSubVW(z, x []uint64, y uint64) uint64 {
+	var carry, b uint64
+	for i, xi := range x {
+		hi, lo := bits.Mul64(xi, y)
+		var c uint64
+		lo, c = bits.Add64(lo, carry, 0)
+		hi += c
+		z[i], b = bits.Sub64(z[i], lo, 0)
+		carry = hi + b
+	}
+	z[len(x)], b = bits.Sub64(z[len(x)], carry, 0)
+	return b
+}
+
+// divWW sets q = u / v and r = u % v, where v is a single word.
+// The quotient q must have at least len(u) words.
+func divWW(q, u []uint64, v uint64) (r uint64) {
+	for j := len(u) - 1; j >= 0; j-- {
+		q[j], r = bits.Div64(r, u[j], v)
+	}
+	return r
+}
+
+// divVV sets q = u / v and r = u % v using Knuth's algorithm D.
+//
+// The divisor v must be normalized (no most significant zero words) and
+// non-zero, len(u) must not exceed MaxWords. The quotient q must have at
+// least len(u)-len(v)+1 words, the remainder r must have len(v) words.
+func divVV(q, r, u, v []uint64) {
+	n := len(v)
+	if len(u) < n {
+		copy(r, u)
+		for i := len(u); i < n; i++ {
+			r[i] = 0
+		}
+		for i := range q {
+			q[i] = 0
+		}
+		return
+	}
+	if n == 1 {
+		r[0] = divWW(q, u, v[0])
+		return
+	}
+	m := len(u) - n
+
+	// normalize: the most significant bit of divisor is set
+	s := uint(bits.LeadingZeros64(v[n-1]))
+	var vbuf [MaxWords]uint64
+	var ubuf [MaxWords + 1]uint64
+	vn, un := vbuf[:n], ubuf[:len(u)+1]
+	shlVU(vn, v, s)
+	un[len(u)] = shlVU(un[:len(u)], u, s)
+
+	for j := m; j >= 0; j-- {
+		// estimate the quotient word, at most 2 too big
+		qhat := ^uint64(0)
+		if ujn := un[j+n]; ujn < vn[n-1] {
+			var rhat uint64
+			qhat, rhat = bits.Div64(ujn, un[j+n-1], vn[n-1])
+			for {
+				hi, lo := bits.Mul64(qhat, vn[n-2])
+				if hi < rhat || hi == rhat && lo <= un[j+n-2] {
+					break
+				}
+				qhat--
+				prev := rhat
+				if rhat += vn[n-1]; rhat < prev {
+					break // rhat >= 2^64
+				}
+			}
+		}
+
+		// multiply and subtract, add back if the estimate was too big
+		for b := mulSubVW(un[j:j+n+1], vn, qhat); b != 0; {
+			qhat--
+			b -= addVV(un[j:j+n+1], vn)
+		}
+		q[j] = qhat
+	}
+	for i := m + 1; i < len(q); i++ {
+		q[i] = 0
+	}
+
+	shrVU(r, un[:n], s)
+}
+
+// shlVU sets z = x << s for s < 64 and returns the shifted out bits.
+func shlVU(z, x []uint64, s uint) uint64 {
+	if s == 0 {
+		copy(z, x)
+		return 0
+	}
+	var c uint64
+	for i, xi := range x {
+		z[i] = xi<<s | c
+		c = xi >> (64 - s)
+	}
+	return c
+}
+
+// shrVU sets z = x >> s for s < 64.
+func shrVU(z, x []uint64, s uint) {
+	if s == 0 {
+		copy(z, x)
+		return
+	}
+	for i := 0; i < len(x)-1; i++ {
+		z[i] = x[i]>>s | x[i+1]<<(64-s)
+	}
+	z[len(x)-1] = x[len(x)-1] >> s
+}
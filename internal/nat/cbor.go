@@ -0,0 +1,137 @@
+package nat
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+)
+
+// CBOR decoding errors.
+var (
+	errCBORTruncated  = errors.New("cbor: unexpected end of data")
+	errCBORType       = errors.New("cbor: expected unsigned integer or bignum")
+	errCBORNegative   = errors.New("cbor: negative integer")
+	errCBORIndefinite = errors.New("cbor: indefinite length byte string is not supported")
+	errCBOROverflow   = errors.New("cbor: integer overflow")
+)
+
+// CBOR major types and tags, see RFC 8949.
+const (
+	cborUint    = 0 << 5
+	cborNegInt  = 1 << 5
+	cborBytes   = 2 << 5
+	cborTag     = 6 << 5
+	cborPosBig  = 2 // tag of positive bignum
+	cborNegBig  = 3 // tag of negative bignum
+	cborInfoMax = 27
+)
+
+// appendCBORHead appends the shortest head of major type with argument v.
+func appendCBORHead(dst []byte, major byte, v uint64) []byte {
+	switch {
+	case v < 24:
+		return append(dst, major|byte(v))
+	case v <= 0xff:
+		return append(dst, major|24, byte(v))
+	case v <= 0xffff:
+		return binary.BigEndian.AppendUint16(append(dst, major|25), uint16(v))
+	case v <= 0xffffffff:
+		return binary.BigEndian.AppendUint32(append(dst, major|26), uint32(v))
+	}
+	return binary.BigEndian.AppendUint64(append(dst, major|27), v)
+}
+
+// readCBORHead reads the head from the front of b and returns
+// major type, argument and length of the head.
+func readCBORHead(b []byte) (major byte, v uint64, n int, err error) {
+	if len(b) == 0 {
+		return 0, 0, 0, errCBORTruncated
+	}
+	major, info := b[0]&0xe0, b[0]&0x1f
+	if info < 24 {
+		return major, uint64(info), 1, nil
+	}
+	if info > cborInfoMax {
+		if info == 31 && major == cborBytes {
+			return major, 0, 0, errCBORIndefinite
+		}
+		return major, 0, 0, errCBORType
+	}
+	n = 1 << (info - 24) // 1, 2, 4 or 8 bytes
+	if len(b) < 1+n {
+		return major, 0, 0, errCBORTruncated
+	}
+	for _, c := range b[1 : 1+n] {
+		v = v<<8 | uint64(c)
+	}
+	return major, v, 1 + n, nil
+}
+
+// AppendCBOR appends the shortest CBOR encoding of x to dst: unsigned
+// integer (major type 0) if x fits 64 bits, otherwise the positive bignum
+// (tag 2) around the byte string of minimal big-endian bytes.
+func AppendCBOR(dst []byte, x []uint64) []byte {
+	x = norm(x)
+	switch len(x) {
+	case 0:
+		return append(dst, cborUint)
+	case 1:
+		return appendCBORHead(dst, cborUint, x[0])
+	}
+
+	dst = appendCBORHead(dst, cborTag, cborPosBig)
+	dst = appendCBORHead(dst, cborBytes, uint64(bitLen(x)+7)/8)
+	top := x[len(x)-1]
+	for i := (bits.Len64(top)+7)/8 - 1; i >= 0; i-- {
+		dst = append(dst, byte(top>>(8*i)))
+	}
+	return AppendBigEndian(dst, x[:len(x)-1])
+}
+
+// DecodeCBOR decodes CBOR unsigned integer or positive bignum from
+// the front of b into z and returns the rest of b. Non-shortest heads
+// and leading zero bytes of bignum are accepted as RFC 8949 requires.
+// Negative integers and bignums, values overflowing len(z) words
+// and other data items are rejected.
+func DecodeCBOR(z []uint64, b []byte) (rest []byte, err error) {
+	for i := range z {
+		z[i] = 0
+	}
+
+	major, v, n, err := readCBORHead(b)
+	if err != nil {
+		return b, err
+	}
+	switch {
+	case major == cborUint:
+		z[0] = v
+		return b[n:], nil
+	case major == cborNegInt, major == cborTag && v == cborNegBig:
+		return b, errCBORNegative
+	case major != cborTag || v != cborPosBig:
+		return b, errCBORType
+	}
+
+	major, v, m, err := readCBORHead(b[n:])
+	if err != nil {
+		return b, err
+	}
+	if major != cborBytes {
+		return b, errCBORType
+	}
+	if v > uint64(len(b)-n-m) {
+		return b, errCBORTruncated
+	}
+	content, rest := b[n+m:n+m+int(v)], b[n+m+int(v):]
+	for len(content) > 0 && content[0] == 0 {
+		content = content[1:]
+	}
+	if len(content) > 8*len(z) {
+		return b, errCBOROverflow
+	}
+	for i, c := range content {
+		k := len(content) - 1 - i // byte index from the least significant
+		z[k/8] |= uint64(c) << (8 * (k % 8))
+	}
+	return rest, nil
+}
@@ -0,0 +1,118 @@
+package nat
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// TestCBOR checks CBOR encoding on RFC 8949 Appendix A vectors.
+func TestCBOR(t *testing.T) {
+	tests := []struct {
+		x    []uint64
+		cbor string
+	}{
+		{[]uint64{0, 0}, "00"},
+		{[]uint64{1}, "01"},
+		{[]uint64{10}, "0a"},
+		{[]uint64{23}, "17"},
+		{[]uint64{24}, "1818"},
+		{[]uint64{25}, "1819"},
+		{[]uint64{100}, "1864"},
+		{[]uint64{1000}, "1903e8"},
+		{[]uint64{1000000}, "1a000f4240"},
+		{[]uint64{1000000000000}, "1b000000e8d4a51000"},
+		{[]uint64{^uint64(0), 0}, "1bffffffffffffffff"},
+		{[]uint64{0, 1}, "c249010000000000000000"},
+		{[]uint64{^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0)}, "c25820" + strings.Repeat("ff", 32)},
+	}
+	for _, tt := range tests {
+		enc := AppendCBOR([]byte{0xf6}, tt.x)
+		if got := hex.EncodeToString(enc[1:]); got != tt.cbor {
+			t.Errorf("AppendCBOR(%#x) mismatch:\n\t(-) expected %s\n\t(+)   actual %s", tt.x, tt.cbor, got)
+		}
+
+		z := make([]uint64, len(tt.x))
+		rest, err := DecodeCBOR(z, append(enc[1:], 0xf6))
+		if err != nil || !bytes.Equal(rest, []byte{0xf6}) {
+			t.Fatalf("DecodeCBOR(%s) failed: %v, rest %x", tt.cbor, err, rest)
+		}
+		if toBig(z).Cmp(toBig(tt.x)) != 0 {
+			t.Errorf("DecodeCBOR(%s) mismatch: %#x", tt.cbor, z)
+		}
+	}
+
+	// non-shortest forms are accepted
+	for _, s := range []string{"1800", "1b0000000000000001", "c240", "c24101", "c2420001", "d80241ff", "c2590001ff"} {
+		b, _ := hex.DecodeString(s)
+		z := make([]uint64, 2)
+		if rest, err := DecodeCBOR(z, b); err != nil || len(rest) != 0 || z[0] > 0xff || z[1] != 0 {
+			t.Errorf("DecodeCBOR(%s) failed: %#x, %v", s, z, err)
+		}
+	}
+}
+
+// TestCBORErrors checks CBOR decoding rejects unsupported and invalid inputs.
+func TestCBORErrors(t *testing.T) {
+	tests := []struct {
+		cbor string
+		err  error
+	}{
+		{"", errCBORTruncated},
+		{"18", errCBORTruncated},
+		{"1b00", errCBORTruncated},
+		{"c2", errCBORTruncated},
+		{"c242ff", errCBORTruncated},
+		{"1c", errCBORType},
+		{"1f", errCBORType},
+		{"f6", errCBORType},
+		{"60", errCBORType},
+		{"c1", errCBORType},
+		{"c260", errCBORType},
+		{"20", errCBORNegative},
+		{"3bffffffffffffffff", errCBORNegative},
+		{"c349010000000000000000", errCBORNegative},
+		{"c25f41ff41ffff", errCBORIndefinite},
+		{"c25821" + "01" + strings.Repeat("00", 32), errCBOROverflow},
+	}
+	for _, tt := range tests {
+		b, _ := hex.DecodeString(tt.cbor)
+		z := make([]uint64, 4)
+		if _, err := DecodeCBOR(z, b); err != tt.err {
+			t.Errorf("DecodeCBOR(%s) should fail with %v, got %v", tt.cbor, tt.err, err)
+		}
+	}
+}
+
+// TestCBORRand checks CBOR encoding against big.Int reference on random values.
+func TestCBORRand(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		x := randWords(r)
+		b := toBig(x)
+
+		var expected []byte
+		if b.IsUint64() {
+			expected = appendCBORHead(nil, cborUint, b.Uint64())
+		} else {
+			expected = appendCBORHead([]byte{0xc2}, cborBytes, uint64(len(b.Bytes())))
+			expected = append(expected, b.Bytes()...)
+		}
+		enc := AppendCBOR(nil, x)
+		if !bytes.Equal(enc, expected) {
+			t.Fatalf("AppendCBOR(%#x) mismatch:\n\t(-) expected %x\n\t(+)   actual %x", x, expected, enc)
+		}
+
+		z := make([]uint64, len(x))
+		if rest, err := DecodeCBOR(z, enc); err != nil || len(rest) != 0 || toBig(z).Cmp(b) != 0 {
+			t.Fatalf("DecodeCBOR(%x) mismatch: %#x, %v", enc, z, err)
+		}
+		if n := len(norm(x)); n > 1 {
+			if _, err := DecodeCBOR(z[:n-1], enc); err != errCBOROverflow {
+				t.Fatalf("DecodeCBOR(%x) into fewer words should overflow, got %v", enc, err)
+			}
+		}
+	}
+}
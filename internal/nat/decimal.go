@@ -0,0 +1,177 @@
+package nat
+
+// Decimal conversion is divide-and-conquer: a number is split by one of
+// the precomputed powers 10^(19*2^k) into two halves of about the same
+// size which are converted recursively. Small numbers are converted word
+// by word using the largest power of 10 that fits in a uint64.
+
+const (
+	// leafWords is the maximum number of words converted to digits without splitting.
+	leafWords = 8
+
+	// leafDigits is the maximum number of digits parsed without splitting.
+	leafDigits = 8 * 19
+)
+
+// smallDigits are all two-digit decimal numbers.
+const smallDigits = "00010203040506070809" +
+	"10111213141516171819" +
+	"20212223242526272829" +
+	"30313233343536373839" +
+	"40414243444546474849" +
+	"50515253545556575859" +
+	"60616263646566676869" +
+	"70717273747576777879" +
+	"80818283848586878889" +
+	"90919293949596979899"
+
+// decimalPower is a precomputed 10^(19*2^k) value.
+type decimalPower struct {
+	words  []uint64 // normalized
+	bits   int      // bit length
+	digits int      // 19*2^k
+}
+
+// decimalPowers are 10^19, 10^38, 10^76, 10^152 and 10^304, all fit in MaxWords.
+var decimalPowers = func() (t [5]decimalPower) {
+	t[0] = decimalPower{words: []uint64{1e19}, digits: 19}
+	for k := 1; k < len(t); k++ {
+		p := t[k-1].words
+		w := make([]uint64, 2*len(p))
+		mulVV(w, p, p)
+		t[k] = decimalPower{words: norm(w), digits: 2 * t[k-1].digits}
+	}
+	for k := range t {
+		t[k].bits = bitLen(t[k].words)
+	}
+	return t
+}()
+
+// putDecimal writes decimal digits of non-zero x.
+func putDecimal(buf []byte, x []uint64) int {
+	return putDecimalPad(buf, x, 0)
+}
+
+// putDecimalPad writes decimal digits of x padded with leading zeros to at least pad digits.
+// If pad is 0, x must be non-zero.
+func putDecimalPad(buf []byte, x []uint64, pad int) int {
+	x = norm(x)
+	if len(x) <= leafWords {
+		return putDecimalLeaf(buf, x, pad)
+	}
+
+	// the largest power which is about square root of x
+	half := bitLen(x) / 2
+	k := len(decimalPowers) - 1
+	for k > 0 && decimalPowers[k].bits > half {
+		k--
+	}
+	p := decimalPowers[k]
+
+	var q, r [MaxWords]uint64
+	divVV(q[:], r[:len(p.words)], x, p.words)
+
+	// lower part is always padded
+	i := putDecimalPad(buf, r[:len(p.words)], p.digits)
+	if pad > 0 {
+		pad -= p.digits
+	}
+	return putDecimalPad(buf[:i], q[:len(x)-len(p.words)+1], pad)
+}
+
+// putDecimalLeaf writes decimal digits of x padded with leading zeros to at least pad digits.
+func putDecimalLeaf(buf []byte, x []uint64, pad int) int {
+	var q [MaxWords]uint64
+	n := copy(q[:], x)
+
+	i := len(buf)
+	for n > 0 {
+		// divide by the largest power of 10 that fits in a uint64
+		r := divWW(q[:n], q[:n], 1e19)
+		for n > 0 && q[n-1] == 0 {
+			n--
+		}
+
+		// two digits at once, the last chunk has no leading zeros
+		k := 0
+		for ; k < 18 && (n > 0 || r >= 10); k += 2 {
+			d := r % 100 * 2
+			r /= 100
+			i -= 2
+			buf[i], buf[i+1] = smallDigits[d], smallDigits[d+1]
+		}
+		if k < 19 && (n > 0 || r != 0) {
+			i--
+			buf[i] = byte('0' + r)
+		}
+	}
+	for end := len(buf) - pad; i > end; {
+		i--
+		buf[i] = '0'
+	}
+	return i
+}
+
+// parseDecimal sets z to the value of s and reports whether there is no overflow.
+// All characters of s must be decimal digits.
+func parseDecimal[S ~string | ~[]byte](z []uint64, s S) bool {
+	if len(s) <= leafDigits {
+		return parseDecimalLeaf(z, s)
+	}
+
+	// the lower part has 19*2^k digits, the largest power less than len(s)
+	k := len(decimalPowers) - 1
+	for k > 0 && decimalPowers[k].digits >= len(s) {
+		k--
+	}
+	p := decimalPowers[k]
+	split := len(s) - p.digits
+
+	var hi [MaxWords]uint64
+	if !parseDecimal(hi[:len(z)], s[:split]) || !parseDecimal(z, s[split:]) {
+		return false
+	}
+
+	// z = hi*p + z
+	h := norm(hi[:len(z)])
+	if len(h) == 0 {
+		return true
+	}
+	if len(h)+len(p.words)-1 > len(z) {
+		return false
+	}
+	var t [2 * MaxWords]uint64
+	prod := t[:len(h)+len(p.words)]
+	mulVV(prod, h, p.words)
+	if len(norm(prod)) > len(z) {
+		return false
+	}
+	return addVV(z, norm(prod)) == 0
+}
+
+// parseDecimalLeaf sets z to the value of s and reports whether there is no overflow.
+func parseDecimalLeaf[S ~string | ~[]byte](z []uint64, s S) bool {
+	for i := range z {
+		z[i] = 0
+	}
+	used := 0 // number of words of z in use
+	for len(s) > 0 {
+		n, m := 19, uint64(1e19)
+		if len(s) < n {
+			n, m = len(s), 1
+			for j := 0; j < n; j++ {
+				m *= 10
+			}
+		}
+		var acc uint64
+		for i := 0; i < n; i++ {
+			acc = acc*10 + uint64(s[i]-'0')
+		}
+		var ok bool
+		if used, ok = mulAddWord(z, used, m, acc); !ok {
+			return false
+		}
+		s = s[n:]
+	}
+	return true
+}
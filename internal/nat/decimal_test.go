@@ -0,0 +1,110 @@
+package nat
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// TestDivVV checks Knuth division against big.Int.
+func TestDivVV(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		u, v := randWords(r), norm(randWords(r))
+		if len(v) == 0 {
+			continue
+		}
+		if r.Intn(4) == 0 {
+			v[len(v)-1] = ^uint64(0) >> uint(r.Intn(2)) // the hardest estimates
+		}
+
+		var q, rem [MaxWords]uint64
+		divVV(q[:], rem[:len(v)], u, v)
+
+		eq, er := new(big.Int).QuoRem(toBig(u), toBig(v), new(big.Int))
+		if toBig(q[:]).Cmp(eq) != 0 || toBig(rem[:len(v)]).Cmp(er) != 0 {
+			t.Fatalf("divVV(%#x, %#x) mismatch:\n\t(-) expected %#x, %#x\n\t(+)   actual %#x, %#x", u, v, eq, er, q, rem[:len(v)])
+		}
+	}
+}
+
+// TestDecimal checks divide-and-conquer decimal conversion against big.Int.
+func TestDecimal(t *testing.T) {
+	values := []*big.Int{}
+	ten := big.NewInt(10)
+	for e := int64(0); e <= 308; e++ {
+		p := new(big.Int).Exp(ten, big.NewInt(e), nil)
+		values = append(values, p, new(big.Int).Sub(p, big.NewInt(1)), new(big.Int).Add(p, big.NewInt(1)))
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		values = append(values, toBig(randWords(r)))
+	}
+
+	for _, b := range values {
+		x, _ := fromBig(b, MaxWords)
+		s := b.String()
+		if got := string(AppendDigits(nil, x, 10)); got != s {
+			t.Fatalf("AppendDigits(%#x, 10) mismatch:\n\t(-) expected %q\n\t(+)   actual %q", b, s, got)
+		}
+
+		for _, s := range []string{s, "000" + s, strings.Repeat("0", 400) + s} {
+			for n := len(norm(x)); n <= MaxWords; n++ {
+				z := make([]uint64, n)
+				if err := ParseUint(z, s, 10); err != nil {
+					t.Fatalf("ParseUint(%q) into %d words failed: %v", s, n, err)
+				}
+				if toBig(z).Cmp(b) != 0 {
+					t.Fatalf("ParseUint(%q) into %d words mismatch: %#x", s, n, z)
+				}
+			}
+		}
+	}
+
+	// overflow is detected on the longest input
+	s := strings.Repeat("9", 400)
+	var z [MaxWords]uint64
+	if err := ParseUint(z[:], s, 10); err == nil {
+		t.Fatalf("ParseUint(%q) should fail", s)
+	}
+}
+
+// BenchmarkDecimal compares decimal conversion with big.Int.
+func BenchmarkDecimal(b *testing.B) {
+	for _, n := range []int{2, 4, 8, 16} {
+		x := make([]uint64, n)
+		for i := range x {
+			x[i] = rand.Uint64()
+		}
+		xb := toBig(x)
+		s := xb.String()
+		buf := make([]byte, 0, maxDigits)
+
+		b.Run(fmt.Sprintf("Format/nat/%d", n*64), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				buf = AppendDigits(buf[:0], x, 10)
+			}
+		})
+		b.Run(fmt.Sprintf("Format/big.Int/%d", n*64), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				buf = xb.Append(buf[:0], 10)
+			}
+		})
+		b.Run(fmt.Sprintf("Parse/nat/%d", n*64), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = ParseUint(x, s, 10)
+			}
+		})
+		b.Run(fmt.Sprintf("Parse/big.Int/%d", n*64), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _ = xb.SetString(s, 10)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package nat
+
+import (
+	"errors"
+	"math/bits"
+)
+
+// DER decoding errors.
+var (
+	errDERTruncated  = errors.New("der: data truncated")
+	errDERTag        = errors.New("der: expected INTEGER")
+	errDERLength     = errors.New("der: non-minimal length")
+	errDEREmpty      = errors.New("der: empty integer")
+	errDERNonMinimal = errors.New("der: integer not minimally-encoded")
+	errDERNegative   = errors.New("der: negative integer")
+	errDEROverflow   = errors.New("der: integer overflow")
+)
+
+// derInteger is the tag of universal primitive INTEGER.
+const derInteger = 0x02
+
+// AppendDERContent appends the content octets of ASN.1 INTEGER x to dst:
+// minimal big-endian two's complement bytes, i.e. with leading 0x00
+// if the high bit is set. Zero is the single 0x00 byte.
+func AppendDERContent(dst []byte, x []uint64) []byte {
+	x = norm(x)
+	if len(x) == 0 {
+		return append(dst, 0)
+	}
+	top := x[len(x)-1]
+	n := (bits.Len64(top) + 7) / 8
+	if top>>(8*n-1) != 0 {
+		dst = append(dst, 0) // keep it positive
+	}
+	for i := n - 1; i >= 0; i-- {
+		dst = append(dst, byte(top>>(8*i)))
+	}
+	return AppendBigEndian(dst, x[:len(x)-1])
+}
+
+// AppendDER appends DER encoded ASN.1 INTEGER x to dst: tag, length and content.
+func AppendDER(dst []byte, x []uint64) []byte {
+	x = norm(x)
+	n := (bitLen(x) + 8) / 8 // content length incl. leading zero
+	dst = append(dst, derInteger)
+	if n < 0x80 {
+		dst = append(dst, byte(n))
+	} else {
+		m := (bits.Len(uint(n)) + 7) / 8 // length of length
+		dst = append(dst, 0x80|byte(m))
+		for i := m - 1; i >= 0; i-- {
+			dst = append(dst, byte(n>>(8*i)))
+		}
+	}
+	return AppendDERContent(dst, x)
+}
+
+// DecodeDERContent decodes the content octets of ASN.1 INTEGER into z.
+// Non-minimal encodings, negative values and values overflowing
+// len(z) words are rejected.
+func DecodeDERContent(z []uint64, content []byte) error {
+	for i := range z {
+		z[i] = 0
+	}
+	switch {
+	case len(content) == 0:
+		return errDEREmpty
+	case len(content) > 1 && (content[0] == 0 && content[1]&0x80 == 0 ||
+		content[0] == 0xff && content[1]&0x80 != 0):
+		return errDERNonMinimal
+	case content[0]&0x80 != 0:
+		return errDERNegative
+	case content[0] == 0:
+		content = content[1:]
+	}
+	if len(content) > 8*len(z) {
+		return errDEROverflow
+	}
+	for i, c := range content {
+		k := len(content) - 1 - i // byte index from the least significant
+		z[k/8] |= uint64(c) << (8 * (k % 8))
+	}
+	return nil
+}
+
+// DecodeDER decodes DER encoded ASN.1 INTEGER from the front of b into z
+// and returns the rest of b. Only the universal primitive INTEGER tag and
+// definite minimal length are accepted, see also DecodeDERContent.
+func DecodeDER(z []uint64, b []byte) (rest []byte, err error) {
+	for i := range z {
+		z[i] = 0
+	}
+	if len(b) < 2 {
+		return b, errDERTruncated
+	}
+	if b[0] != derInteger {
+		return b, errDERTag
+	}
+
+	n, h := int(b[1]), 2 // content length and header length
+	if n >= 0x80 {
+		m := n & 0x7f // length of length
+		if m == 0 || m > 4 {
+			return b, errDERLength // indefinite or too long
+		}
+		if len(b) < 2+m {
+			return b, errDERTruncated
+		}
+		if b[2] == 0 {
+			return b, errDERLength
+		}
+		n, h = 0, 2+m
+		for _, c := range b[2:h] {
+			n = n<<8 | int(c)
+		}
+		if n < 0x80 {
+			return b, errDERLength // must be short form
+		}
+	}
+	if n > len(b)-h {
+		return b, errDERTruncated
+	}
+	if err := DecodeDERContent(z, b[h:h+n]); err != nil {
+		return b, err
+	}
+	return b[h+n:], nil
+}
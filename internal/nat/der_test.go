@@ -0,0 +1,101 @@
+package nat
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"encoding/hex"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// TestDER checks DER encoding on known vectors.
+func TestDER(t *testing.T) {
+	tests := []struct {
+		x   []uint64
+		der string
+	}{
+		{[]uint64{0, 0}, "020100"},
+		{[]uint64{1}, "020101"},
+		{[]uint64{0x7f}, "02017f"},
+		{[]uint64{0x80}, "02020080"},
+		{[]uint64{0x100}, "02020100"},
+		{[]uint64{^uint64(0)}, "020900ffffffffffffffff"},
+		{[]uint64{0, 1}, "0209010000000000000000"},
+		{[]uint64{^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0)}, "022100" + strings.Repeat("ff", 32)},
+		{[]uint64{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1 << 63}, "0281810080" + strings.Repeat("00", 127)},
+	}
+	for _, tt := range tests {
+		enc := AppendDER([]byte{0x05}, tt.x)
+		if got := hex.EncodeToString(enc[1:]); got != tt.der {
+			t.Errorf("AppendDER(%#x) mismatch:\n\t(-) expected %s\n\t(+)   actual %s", tt.x, tt.der, got)
+		}
+
+		z := make([]uint64, len(tt.x))
+		rest, err := DecodeDER(z, append(enc[1:], 0x05))
+		if err != nil || !bytes.Equal(rest, []byte{0x05}) {
+			t.Fatalf("DecodeDER(%s) failed: %v, rest %x", tt.der, err, rest)
+		}
+		if toBig(z).Cmp(toBig(tt.x)) != 0 {
+			t.Errorf("DecodeDER(%s) mismatch: %#x", tt.der, z)
+		}
+	}
+}
+
+// TestDERErrors checks DER decoding rejects non-minimal and invalid inputs.
+func TestDERErrors(t *testing.T) {
+	tests := []struct {
+		der string
+		err error
+	}{
+		{"", errDERTruncated},
+		{"02", errDERTruncated},
+		{"0202ff", errDERTruncated},
+		{"0281", errDERTruncated},
+		{"0400", errDERTag},
+		{"2200", errDERTag},
+		{"0200", errDEREmpty},
+		{"02020001", errDERNonMinimal},
+		{"0202ff80", errDERNonMinimal},
+		{"0201ff", errDERNegative},
+		{"020180", errDERNegative},
+		{"02810101", errDERLength},
+		{"0280", errDERLength},
+		{"0282000101", errDERLength},
+		{"022101" + strings.Repeat("00", 32), errDEROverflow},
+		{"02220000" + strings.Repeat("ff", 32), errDERNonMinimal},
+	}
+	for _, tt := range tests {
+		b, _ := hex.DecodeString(tt.der)
+		z := make([]uint64, 4)
+		if _, err := DecodeDER(z, b); err != tt.err {
+			t.Errorf("DecodeDER(%s) should fail with %v, got %v", tt.der, tt.err, err)
+		}
+	}
+}
+
+// TestDERRand checks DER encoding against encoding/asn1 on random values.
+func TestDERRand(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		x := randWords(r)
+		expected, err := asn1.Marshal(toBig(x))
+		if err != nil {
+			t.Fatalf("asn1.Marshal failed: %v", err)
+		}
+		enc := AppendDER(nil, x)
+		if !bytes.Equal(enc, expected) {
+			t.Fatalf("AppendDER(%#x) mismatch:\n\t(-) expected %x\n\t(+)   actual %x", x, expected, enc)
+		}
+
+		z := make([]uint64, len(x))
+		if rest, err := DecodeDER(z, enc); err != nil || len(rest) != 0 || toBig(z).Cmp(toBig(x)) != 0 {
+			t.Fatalf("DecodeDER(%x) mismatch: %#x, %v", enc, z, err)
+		}
+		if n := len(norm(x)); n > 0 {
+			if _, err := DecodeDER(z[:n-1], enc); err != errDEROverflow {
+				t.Fatalf("DecodeDER(%x) into fewer words should overflow, got %v", enc, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package nat
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+const (
+	lowerDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+	upperDigits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+)
+
+// maxDigits is the maximum number of digits of a 1024-bit value (in base 2).
+const maxDigits = MaxWords * 64
+
+// maxDecimalDigits is the maximum number of decimal digits of a 1024-bit value.
+const maxDecimalDigits = 309
+
+// AppendDigits appends digits of x in given base to dst.
+// Base must be in range [2, 36]. Letter digits are lower case.
+func AppendDigits(dst []byte, x []uint64, base int) []byte {
+	if base == 10 {
+		var buf [maxDecimalDigits]byte // smaller buffer for the most common case
+		i := putDigits(buf[:], x, base, lowerDigits)
+		return append(dst, buf[i:]...)
+	}
+
+	var buf [maxDigits]byte
+	i := putDigits(buf[:], x, base, lowerDigits)
+	return append(dst, buf[i:]...)
+}
+
+// putDigits writes digits of x to the end of buf and returns index of the first digit.
+func putDigits(buf []byte, x []uint64, base int, digits string) int {
+	x = norm(x)
+	if len(x) == 0 {
+		buf[len(buf)-1] = '0'
+		return len(buf) - 1
+	}
+
+	switch base {
+	case 2:
+		return putDigitsPow2(buf, x, 1, digits)
+	case 4:
+		return putDigitsPow2(buf, x, 2, digits)
+	case 8:
+		return putDigitsPow2(buf, x, 3, digits)
+	case 16:
+		return putDigitsPow2(buf, x, 4, digits)
+	case 32:
+		return putDigitsPow2(buf, x, 5, digits)
+	case 10:
+		return putDecimal(buf, x)
+	}
+	if base < 2 || base > 36 {
+		panic("nat: illegal base")
+	}
+	return putDigitsDiv(buf, x, base, digits)
+}
+
+// putDigitsPow2 writes digits of non-zero x in base 2^shift.
+func putDigitsPow2(buf []byte, x []uint64, shift uint, digits string) int {
+	mask := uint64(1)<<shift - 1
+	i := len(buf)
+
+	if 64%shift == 0 {
+		// digits never span words: the fixed number of digits per word
+		for _, w := range x[:len(x)-1] {
+			for k := uint(0); k < 64; k += shift {
+				i--
+				buf[i] = digits[w&mask]
+				w >>= shift
+			}
+		}
+		for w := x[len(x)-1]; w != 0; w >>= shift {
+			i--
+			buf[i] = digits[w&mask]
+		}
+		return i
+	}
+
+	var acc uint64 // bits left from the previous word
+	var nacc uint  // number of bits in acc
+	for j, w := range x {
+		avail := uint(64)
+		if nacc > 0 {
+			// the digit spans two words
+			i--
+			buf[i] = digits[(acc|w<<nacc)&mask]
+			w >>= shift - nacc
+			avail -= shift - nacc
+		}
+		last := j == len(x)-1
+		for ; avail >= shift && (w != 0 || !last); avail -= shift {
+			i--
+			buf[i] = digits[w&mask]
+			w >>= shift
+		}
+		acc, nacc = w, avail
+	}
+	if acc != 0 {
+		i--
+		buf[i] = digits[acc]
+	}
+	return i
+}
+
+// putDigitsDiv writes digits of non-zero x in any base.
+// It is the same as putDecimalLeaf but without constant divisor.
+func putDigitsDiv(buf []byte, x []uint64, base int, digits string) int {
+	var q [MaxWords]uint64
+	n := copy(q[:], x)
+
+	bb := bigBases[base]
+	b := uint64(base)
+	i := len(buf)
+	for n > 0 {
+		var r uint64
+		for j := n - 1; j >= 0; j-- {
+			q[j], r = bits.Div64(r, q[j], bb.b)
+		}
+		for n > 0 && q[n-1] == 0 {
+			n--
+		}
+
+		for k := 0; k < bb.n && (n > 0 || r != 0); k++ {
+			i--
+			buf[i] = digits[r%b]
+			r /= b
+		}
+	}
+	return i
+}
+
+// Format implements fmt.Formatter for x.
+//
+// The output is exactly the same as of (*big.Int).Format for the same
+// non-negative value, including the width, precision and flags handling.
+// Supported verbs are 'b', 'o', 'O', 'd', 's', 'v', 'x' and 'X'.
+func Format(s fmt.State, ch rune, x []uint64) {
+	var base int
+	switch ch {
+	case 'b':
+		base = 2
+	case 'o', 'O':
+		base = 8
+	case 'd', 's', 'v':
+		base = 10
+	case 'x', 'X':
+		base = 16
+	default:
+		// unknown format, big.Int compatible
+		fmt.Fprintf(s, "%%!%c(big.Int=%s)", ch, AppendDigits(nil, x, 10))
+		return
+	}
+
+	// the sign character, non-negative only
+	sign := ""
+	switch {
+	case s.Flag('+'):
+		sign = "+"
+	case s.Flag(' '):
+		sign = " "
+	}
+
+	// the prefix characters to indicate base
+	prefix := ""
+	if s.Flag('#') {
+		switch ch {
+		case 'b':
+			prefix = "0b"
+		case 'o':
+			prefix = "0"
+		case 'x':
+			prefix = "0x"
+		case 'X':
+			prefix = "0X"
+		}
+	}
+	if ch == 'O' {
+		prefix = "0o"
+	}
+
+	var buf [maxDigits]byte
+	digits := lowerDigits
+	if ch == 'X' {
+		digits = upperDigits
+	}
+	digs := buf[putDigits(buf[:], x, base, digits):]
+
+	var left int  // spaces to the left of digits for right justification ("%8d")
+	var zeros int // zeros as left-most digits ("%.8d")
+	var right int // spaces to the right of digits for left justification ("%-8d")
+
+	// precision is the least number of digits to output
+	precision, precisionSet := s.Precision()
+	if precisionSet {
+		switch {
+		case len(digs) < precision:
+			zeros = precision - len(digs)
+		case len(digs) == 1 && digs[0] == '0' && precision == 0:
+			return // print nothing if zero value and zero precision ("." or ".0")
+		}
+	}
+
+	// width is the least number of characters to output
+	length := len(sign) + len(prefix) + zeros + len(digs)
+	if width, widthSet := s.Width(); widthSet && length < width {
+		switch d := width - length; {
+		case s.Flag('-'):
+			right = d // supersedes '0' when both specified
+		case s.Flag('0') && !precisionSet:
+			zeros = d // unless precision also specified
+		default:
+			left = d
+		}
+	}
+
+	// [left pad][sign][prefix][zero pad][digits][right pad] written at once
+	out := make([]byte, 0, left+len(sign)+len(prefix)+zeros+len(digs)+right)
+	out = appendRepeat(out, ' ', left)
+	out = append(out, sign...)
+	out = append(out, prefix...)
+	out = appendRepeat(out, '0', zeros)
+	out = append(out, digs...)
+	out = appendRepeat(out, ' ', right)
+	s.Write(out)
+}
+
+// appendRepeat appends n copies of c to dst.
+func appendRepeat(dst []byte, c byte, n int) []byte {
+	for ; n > 0; n-- {
+		dst = append(dst, c)
+	}
+	return dst
+}
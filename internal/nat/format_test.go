@@ -0,0 +1,114 @@
+package nat
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// formatter wraps words to test Format via fmt package.
+type formatter []uint64
+
+// Format implements fmt.Formatter.
+func (x formatter) Format(s fmt.State, ch rune) {
+	Format(s, ch, x)
+}
+
+// toBig converts words to big.Int.
+func toBig(x []uint64) *big.Int {
+	b := new(big.Int)
+	for i := len(x) - 1; i >= 0; i-- {
+		b.Lsh(b, 64)
+		b.Or(b, new(big.Int).SetUint64(x[i]))
+	}
+	return b
+}
+
+// randWords generates random words with a random number of leading zero words.
+func randWords(r *rand.Rand) []uint64 {
+	x := make([]uint64, 1+r.Intn(MaxWords))
+	for i := r.Intn(len(x) + 1); i < len(x); i++ {
+		x[i] = r.Uint64()
+		if r.Intn(4) == 0 {
+			x[i] >>= uint(r.Intn(64)) // more short words
+		}
+	}
+	for i := range x[:r.Intn(len(x)+1)] {
+		x[len(x)-1-i] = 0
+	}
+	return x
+}
+
+// formats is a list of format strings checked for big.Int compatibility.
+var formats = []string{
+	"%b", "%o", "%O", "%d", "%s", "%v", "%x", "%X", "%q", "%c",
+	"%#b", "%#o", "%#O", "%#d", "%#x", "%#X",
+	"%+d", "% d", "%+x", "% #x",
+	"%5d", "%-5d", "%05d", "%50d", "%-50x", "%050x", "%#050x", "%+050d",
+	"%.0d", "%.d", "%5.0d", "%-5.0x", "%.3d", "%.70x", "%010.5d", "%-#10.5x",
+	"%300b", "%0300b", "%-#300b", "%.400o",
+}
+
+// TestFormat checks Format produces the same output as big.Int.
+func TestFormat(t *testing.T) {
+	values := [][]uint64{
+		nil,
+		{0},
+		{0, 0},
+		{1},
+		{1, 0, 0},
+		{^uint64(0)},
+		{0, 1},
+		{1e19 - 1, 0},
+		{1e19, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1 << 63},
+	}
+	max := make([]uint64, MaxWords)
+	for i := range max {
+		max[i] = ^uint64(0)
+	}
+	values = append(values, max)
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		values = append(values, randWords(r))
+	}
+
+	for _, x := range values {
+		b := toBig(x)
+		for _, f := range formats {
+			if expected, got := fmt.Sprintf(f, b), fmt.Sprintf(f, formatter(x)); got != expected {
+				t.Fatalf("Sprintf(%q, %#x) mismatch:\n\t(-) expected %q\n\t(+)   actual %q", f, b, expected, got)
+			}
+		}
+		for _, base := range []int{2, 8, 10, 16} {
+			if expected, got := b.Text(base), string(AppendDigits(nil, x, base)); got != expected {
+				t.Fatalf("AppendDigits(%#x, %d) mismatch:\n\t(-) expected %q\n\t(+)   actual %q", b, base, expected, got)
+			}
+		}
+	}
+}
+
+// BenchmarkFormat compares Format with big.Int.
+func BenchmarkFormat(b *testing.B) {
+	b.ReportAllocs()
+
+	x := make([]uint64, 4)
+	for i := range x {
+		x[i] = rand.Uint64()
+	}
+	xb := toBig(x)
+
+	b.Run("nat", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = fmt.Sprintf("%#x", formatter(x))
+		}
+	})
+
+	b.Run("big.Int", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = fmt.Sprintf("%#x", xb)
+		}
+	})
+}
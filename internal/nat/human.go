@@ -0,0 +1,415 @@
+package nat
+
+import (
+	"errors"
+	"strconv"
+)
+
+// SI prefixes for powers of 1000 and IEC prefixes for powers of 1024.
+var (
+	siPrefixes  = [...]string{"", "k", "M", "G", "T", "P", "E", "Z", "Y", "R", "Q"}
+	iecPrefixes = [...]string{"", "Ki", "Mi", "Gi", "Ti", "Pi", "Ei", "Zi", "Yi"}
+)
+
+// errHumanFraction is returned if the parsed value is not an integer.
+var errHumanFraction = errors.New("value is not an integer")
+
+// AppendGrouped appends decimal x to dst with sep between every group
+// digits counting from the right, e.g. "1,234,567". No grouping if group < 1.
+func AppendGrouped(dst []byte, x []uint64, sep string, group int) []byte {
+	var buf [maxDecimalDigits]byte
+	d := AppendDigits(buf[:0], x, 10)
+	if group < 1 {
+		return append(dst, d...)
+	}
+	for i := 0; i < len(d); i++ {
+		if i > 0 && (len(d)-i)%group == 0 {
+			dst = append(dst, sep...)
+		}
+		dst = append(dst, d[i])
+	}
+	return dst
+}
+
+// roundDigits rounds decimal digits d to n leading digits using
+// round half to even and returns the result, which has n+1 digits
+// if the rounding carries out, e.g. "999" to 2 digits is "100".
+func roundDigits(d []byte, n int) []byte {
+	if n >= len(d) {
+		return d
+	}
+	up := d[n] > '5'
+	if d[n] == '5' {
+		up = n > 0 && (d[n-1]-'0')%2 == 1 // tie
+		for _, c := range d[n+1:] {
+			if c != '0' {
+				up = true
+				break
+			}
+		}
+	}
+	d = d[:n]
+	if !up {
+		return d
+	}
+	for i := n - 1; i >= 0; i-- {
+		if d[i] < '9' {
+			d[i]++
+			return d
+		}
+		d[i] = '0'
+	}
+	return append([]byte{'1'}, d...) // carry out
+}
+
+// fixed splits decimal digits d of a value with p integer digits into
+// integer and fraction digits with prec fraction digits, rounded half
+// to even. If prec < 0 all fraction digits but trailing zeros are kept.
+func fixed(d []byte, p, prec int) (ip, fp []byte) {
+	if prec < 0 {
+		ip, fp = d[:p], d[p:]
+		for len(fp) > 0 && fp[len(fp)-1] == '0' {
+			fp = fp[:len(fp)-1]
+		}
+		return ip, fp
+	}
+	d = roundDigits(append(make([]byte, 0, len(d)+1), d...), p+prec)
+	if len(d) > p+prec {
+		p++ // carry out
+	}
+	for len(d) < p+prec {
+		d = append(d, '0')
+	}
+	return d[:p], d[p:]
+}
+
+// appendFixed appends integer and fraction digits to dst.
+func appendFixed(dst, ip, fp []byte) []byte {
+	dst = append(dst, ip...)
+	if len(fp) > 0 {
+		dst = append(dst, '.')
+		dst = append(dst, fp...)
+	}
+	return dst
+}
+
+// AppendSI appends x scaled by the largest SI prefix, from k (10^3)
+// to Q (10^30), with prec fraction digits to dst, e.g. "1.23M".
+// If prec < 0 the exact value without trailing zeros is used.
+func AppendSI(dst []byte, x []uint64, prec int) []byte {
+	var buf [maxDecimalDigits]byte
+	d := AppendDigits(buf[:0], x, 10)
+	k := (len(d) - 1) / 3
+	if k >= len(siPrefixes) {
+		k = len(siPrefixes) - 1
+	}
+
+	ip, fp := fixed(d, len(d)-3*k, prec)
+	if string(ip) == "1000" && k+1 < len(siPrefixes) {
+		k++ // rounded up, e.g. "999.99k" to "1.00M"
+		ip, fp = fixed(d, len(d)-3*k, prec)
+	}
+	return append(appendFixed(dst, ip, fp), siPrefixes[k]...)
+}
+
+// AppendSci appends x in scientific notation with prec fraction digits
+// to dst, e.g. "1.2345e+76", the exponent has at least two digits.
+// If prec < 0 the exact value without trailing zeros is used.
+func AppendSci(dst []byte, x []uint64, prec int) []byte {
+	var buf [maxDecimalDigits]byte
+	d := AppendDigits(buf[:0], x, 10)
+	exp := len(d) - 1
+	ip, fp := fixed(d, 1, prec)
+	if len(ip) > 1 { // rounded up to 10
+		ip, exp = ip[:1], exp+1
+	}
+	dst = append(appendFixed(dst, ip, fp), 'e', '+')
+	if exp < 10 {
+		dst = append(dst, '0')
+	}
+	return strconv.AppendInt(dst, int64(exp), 10)
+}
+
+// AppendIEC appends x scaled by the largest IEC prefix, from Ki (2^10)
+// to Yi (2^80), with prec fraction digits to dst, e.g. "1.5Ki".
+// If prec < 0 the exact value without trailing zeros is used.
+func AppendIEC(dst []byte, x []uint64, prec int) []byte {
+	x = norm(x)
+	k := 0
+	if n := bitLen(x); n > 0 {
+		k = (n - 1) / 10
+	}
+	if k >= len(iecPrefixes) {
+		k = len(iecPrefixes) - 1
+	}
+
+	ip, fp := iecFixed(x, k, prec)
+	if string(ip) == "1024" && k+1 < len(iecPrefixes) {
+		k++ // rounded up, e.g. "1023.99Ki" to "1.00Mi"
+		ip, fp = iecFixed(x, k, prec)
+	}
+	return append(appendFixed(dst, ip, fp), iecPrefixes[k]...)
+}
+
+// iecFixed returns integer and fraction digits of x / 2^(10k),
+// see fixed for prec details.
+func iecFixed(x []uint64, k, prec int) (ip, fp []byte) {
+	s := uint(10 * k) // at most 80 bits
+
+	// q = x >> s, r = x & (1<<s - 1)
+	var q [MaxWords]uint64
+	var r [2]uint64
+	w := int(s / 64)
+	if w < len(x) {
+		shrVU(q[:len(x)-w], x[w:], s%64)
+	}
+	copy(r[:], x)
+	if s < 64 {
+		r[0] &= 1<<s - 1
+		r[1] = 0
+	} else {
+		r[1] &= 1<<(s-64) - 1
+	}
+
+	// exact fraction has s digits: r * 10^s / 2^s = r * 5^s
+	pe := int(s)
+	if prec >= 0 && prec < pe {
+		pe = prec
+	}
+
+	// t = r * 10^pe, f = t >> s, rounded half to even by the rest
+	var t [8]uint64
+	n := copy(t[:], norm(r[:]))
+	for i := 0; i < pe; i += 19 {
+		m := bigBases[10]
+		if pe-i < m.n {
+			m.b = pow10(pe - i)
+		}
+		n, _ = mulAddWord(t[:], n, m.b, 0)
+	}
+	var f [8]uint64
+	shrVU(f[:7], t[s/64:s/64+7], s%64)
+	if s > 0 {
+		half := bitAt(t[:], s-1)
+		sticky := false
+		for i := uint(0); i+1 < s; i++ {
+			sticky = sticky || bitAt(t[:], i)
+		}
+		odd := f[0]&1 == 1
+		if pe == 0 {
+			odd = q[0]&1 == 1 // f is zero, the last digit is in q
+		}
+		if half && (sticky || odd) {
+			addOne(f[:])
+		}
+	}
+
+	var buf [maxDecimalDigits]byte
+	fd := AppendDigits(buf[:0], f[:], 10)
+	if len(norm(f[:])) == 0 {
+		fd = fd[:0]
+	}
+	if len(fd) > pe { // rounded up to 1
+		addOne(q[:])
+		fd = fd[:0]
+	}
+
+	ip = AppendDigits(nil, q[:], 10)
+	fp = make([]byte, 0, pe)
+	for i := len(fd); i < pe; i++ {
+		fp = append(fp, '0')
+	}
+	fp = append(fp, fd...)
+	if prec < 0 {
+		for len(fp) > 0 && fp[len(fp)-1] == '0' {
+			fp = fp[:len(fp)-1]
+		}
+	}
+	for len(fp) < prec {
+		fp = append(fp, '0')
+	}
+	return ip, fp
+}
+
+// pow10 returns 10^n for n < 20.
+func pow10(n int) uint64 {
+	p := uint64(1)
+	for ; n > 0; n-- {
+		p *= 10
+	}
+	return p
+}
+
+// pow5 returns 5^n for n < 28.
+func pow5(n int) uint64 {
+	p := uint64(1)
+	for ; n > 0; n-- {
+		p *= 5
+	}
+	return p
+}
+
+// bitAt reports whether bit i of x is set.
+func bitAt(x []uint64, i uint) bool {
+	return x[i/64]>>(i%64)&1 == 1
+}
+
+// addOne sets x = x + 1.
+func addOne(x []uint64) {
+	for i := range x {
+		x[i]++
+		if x[i] != 0 {
+			return
+		}
+	}
+}
+
+// maxHumanLen limits the length of human-readable input.
+const maxHumanLen = 1000
+
+// ParseHuman interprets human-readable s, e.g. "1234", "1.5M", "3e18",
+// "2.5e3k" or "1.5Ki", and stores the result in z. The number is decimal
+// with optional fraction and exponent, followed by optional SI prefix
+// (k or K, M, G, T, P, E, Z, Y, R, Q) or IEC prefix (Ki to Yi).
+// The value must be an integer. Errors are *strconv.NumError.
+// There is no memory allocation unless an error is returned.
+func ParseHuman(z []uint64, s string) error {
+	for i := range z {
+		z[i] = 0
+	}
+	const fn = "ParseHuman"
+	if len(s) == 0 || len(s) > maxHumanLen {
+		return syntaxError(fn, s)
+	}
+
+	// mantissa digits are s[:ip] and s[ip+1:ip+1+frac], at least one integer digit
+	i := 0
+	for ; i < len(s) && '0' <= s[i] && s[i] <= '9'; i++ {
+	}
+	ip := i
+	if ip == 0 {
+		return syntaxError(fn, s)
+	}
+	frac := 0
+	if i < len(s) && s[i] == '.' {
+		for i++; i < len(s) && '0' <= s[i] && s[i] <= '9'; i++ {
+			frac++
+		}
+	}
+	digit := func(j int) uint64 { // j-th mantissa digit
+		if j >= ip {
+			j++ // skip the point
+		}
+		return uint64(s[j] - '0')
+	}
+
+	// exponent
+	exp := 0
+	if i+1 < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		j := i + 1
+		neg := false
+		if s[j] == '+' || s[j] == '-' {
+			neg = s[j] == '-'
+			j++
+		}
+		if j < len(s) && '0' <= s[j] && s[j] <= '9' {
+			for i = j; i < len(s) && '0' <= s[i] && s[i] <= '9'; i++ {
+				if exp = exp*10 + int(s[i]-'0'); exp > maxHumanLen {
+					return syntaxError(fn, s)
+				}
+			}
+			if neg {
+				exp = -exp
+			}
+		}
+	}
+
+	// prefix
+	shift := uint(0)
+	if prefix := s[i:]; prefix != "" {
+		found := false
+		for k := 1; k < len(siPrefixes) && !found; k++ {
+			if prefix == siPrefixes[k] || k == 1 && prefix == "K" {
+				exp, found = exp+3*k, true
+			}
+		}
+		for k := 1; k < len(iecPrefixes) && !found; k++ {
+			if prefix == iecPrefixes[k] {
+				shift, found = uint(10*k), true
+			}
+		}
+		if !found {
+			return syntaxError(fn, s)
+		}
+	}
+
+	// value = mant * 10^e * 2^shift, trailing zeros of mant reduce -e
+	e := exp - frac
+	nd := ip + frac
+	for e < 0 && nd > 0 && digit(nd-1) == 0 {
+		nd, e = nd-1, e+1
+	}
+	if nd == 0 {
+		e = 0 // zero
+	}
+
+	// If e < 0, the last digit is not zero, so mant is not divisible by 10
+	// and 10^-e must divide 5^-e * 2^shift: -e <= shift <= 80. The quotient
+	// by 5^-e must fit z and 5^80 < 2^192, so mant must fit 3 more words.
+	lim := len(z)
+	if e < 0 {
+		if -e > int(shift) {
+			return &strconv.NumError{Func: fn, Num: s, Err: errHumanFraction}
+		}
+		lim += 3
+	}
+	var t [MaxWords + 3]uint64
+	n := 0
+	ok := true
+	var acc uint64
+	for j, c := 0, 0; j < nd; j++ {
+		acc, c = acc*10+digit(j), c+1
+		if c == 19 || j == nd-1 {
+			if n, ok = mulAddWord(t[:lim], n, pow10(c), acc); !ok {
+				return rangeError(fn, z, s)
+			}
+			acc, c = 0, 0
+		}
+	}
+
+	if e < 0 {
+		for k := -e; k > 0; k -= 27 {
+			c := k
+			if c > 27 {
+				c = 27
+			}
+			if divWW(t[:n], t[:n], pow5(c)) != 0 {
+				return &strconv.NumError{Func: fn, Num: s, Err: errHumanFraction}
+			}
+		}
+		if n = len(norm(t[:n])); n > len(z) {
+			return rangeError(fn, z, s)
+		}
+		shift, e = shift-uint(-e), 0
+	}
+	for ; e > 0 && ok; e -= 19 {
+		c := e
+		if c > 19 {
+			c = 19
+		}
+		n, ok = mulAddWord(t[:len(z)], n, pow10(c), 0)
+	}
+	for shift > 0 && ok {
+		c := shift
+		if c > 60 {
+			c = 60
+		}
+		n, ok = mulAddWord(t[:len(z)], n, 1<<c, 0)
+		shift -= c
+	}
+	if !ok {
+		return rangeError(fn, z, s)
+	}
+	copy(z, t[:n])
+	return nil
+}
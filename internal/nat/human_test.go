@@ -0,0 +1,247 @@
+package nat
+
+import (
+	"math/big"
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestGrouped checks thousands separators on known vectors.
+func TestGrouped(t *testing.T) {
+	tests := []struct {
+		x        []uint64
+		sep      string
+		group    int
+		expected string
+	}{
+		{nil, ",", 3, "0"},
+		{[]uint64{999}, ",", 3, "999"},
+		{[]uint64{1000}, ",", 3, "1,000"},
+		{[]uint64{1234567}, ",", 3, "1,234,567"},
+		{[]uint64{1234567}, "_", 4, "123_4567"},
+		{[]uint64{1234567}, " ", 3, "1 234 567"},
+		{[]uint64{1234567}, ",", 0, "1234567"},
+		{[]uint64{123}, "'", 1, "1'2'3"},
+		{[]uint64{^uint64(0)}, ",", 3, "18,446,744,073,709,551,615"},
+	}
+	for _, tt := range tests {
+		if got := string(AppendGrouped(nil, tt.x, tt.sep, tt.group)); got != tt.expected {
+			t.Errorf("AppendGrouped(%d, %q, %d) mismatch: expected %q, got %q", tt.x, tt.sep, tt.group, tt.expected, got)
+		}
+	}
+}
+
+// TestHumanFormat checks SI, IEC and scientific formatting on known vectors.
+func TestHumanFormat(t *testing.T) {
+	pow10 := func(n int) []uint64 {
+		x, _ := fromBig(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil), 4)
+		return x
+	}
+	tests := []struct {
+		f        func([]byte, []uint64, int) []byte
+		x        []uint64
+		prec     int
+		expected string
+	}{
+		{AppendSI, nil, 2, "0.00"},
+		{AppendSI, nil, -1, "0"},
+		{AppendSI, []uint64{999}, 1, "999.0"},
+		{AppendSI, []uint64{1000}, -1, "1k"},
+		{AppendSI, []uint64{1234567}, 2, "1.23M"},
+		{AppendSI, []uint64{1234567}, -1, "1.234567M"},
+		{AppendSI, []uint64{1234567}, 0, "1M"},
+		{AppendSI, []uint64{1500000}, 0, "2M"},
+		{AppendSI, []uint64{2500000}, 0, "2M"},
+		{AppendSI, []uint64{2500001}, 0, "3M"},
+		{AppendSI, []uint64{999995}, 2, "1.00M"},
+		{AppendSI, []uint64{999949}, 2, "999.95k"},
+		{AppendSI, []uint64{^uint64(0)}, 3, "18.447E"},
+		{AppendSI, pow10(30), 1, "1.0Q"},
+		{AppendSI, pow10(36), 1, "1000000.0Q"},
+
+		{AppendIEC, nil, 1, "0.0"},
+		{AppendIEC, []uint64{1023}, 1, "1023.0"},
+		{AppendIEC, []uint64{1024}, -1, "1Ki"},
+		{AppendIEC, []uint64{1536}, 1, "1.5Ki"},
+		{AppendIEC, []uint64{1536}, 0, "2Ki"},
+		{AppendIEC, []uint64{2560}, 0, "2Ki"},
+		{AppendIEC, []uint64{1025}, -1, "1.0009765625Ki"},
+		{AppendIEC, []uint64{1025}, 3, "1.001Ki"},
+		{AppendIEC, []uint64{1048575}, 2, "1.00Mi"},
+		{AppendIEC, []uint64{1048575}, 3, "1023.999Ki"},
+		{AppendIEC, []uint64{^uint64(0)}, 2, "16.00Ei"},
+		{AppendIEC, []uint64{0, 1 << 16}, 0, "1Yi"},
+		{AppendIEC, []uint64{0, 1 << 26}, 0, "1024Yi"},
+
+		{AppendSci, nil, 2, "0.00e+00"},
+		{AppendSci, []uint64{7}, -1, "7e+00"},
+		{AppendSci, []uint64{12345}, -1, "1.2345e+04"},
+		{AppendSci, []uint64{12345}, 2, "1.23e+04"},
+		{AppendSci, []uint64{99999}, 2, "1.00e+05"},
+		{AppendSci, pow10(76), 4, "1.0000e+76"},
+	}
+	for _, tt := range tests {
+		if got := string(tt.f(nil, tt.x, tt.prec)); got != tt.expected {
+			t.Errorf("format(%d, %d) mismatch: expected %q, got %q", tt.x, tt.prec, tt.expected, got)
+		}
+	}
+}
+
+// scaled returns x / base^k with prec fraction digits rounded half to even,
+// bumping k if the rounded value reaches base.
+func scaled(x *big.Int, base int64, k, maxK, prec int) string {
+	num := new(big.Int).Mul(x, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(prec)), nil))
+	den := new(big.Int).Exp(big.NewInt(base), big.NewInt(int64(k)), nil)
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if c := new(big.Int).Lsh(r, 1).Cmp(den); c > 0 || c == 0 && q.Bit(0) == 1 {
+		q.Add(q, big.NewInt(1))
+	}
+	limit := new(big.Int).Mul(big.NewInt(base), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(prec)), nil))
+	if k < maxK && q.Cmp(limit) >= 0 {
+		return scaled(x, base, k+1, maxK, prec)
+	}
+	s := q.String()
+	for len(s) <= prec {
+		s = "0" + s
+	}
+	if prec > 0 {
+		s = s[:len(s)-prec] + "." + s[len(s)-prec:]
+	}
+	return s + strconv.Itoa(k)
+}
+
+// TestHumanRand checks formatting against big.Int and parsing round trips.
+func TestHumanRand(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		x := randWords(r)
+		b := toBig(x)
+		prec := r.Intn(6)
+
+		// index of the prefix instead of the prefix itself
+		index := func(s string, prefixes []string) string {
+			for k := len(prefixes) - 1; k > 0; k-- {
+				if strings.HasSuffix(s, prefixes[k]) {
+					return strings.TrimSuffix(s, prefixes[k]) + strconv.Itoa(k)
+				}
+			}
+			return s + "0"
+		}
+
+		k := (len(b.String()) - 1) / 3
+		if k >= len(siPrefixes) {
+			k = len(siPrefixes) - 1
+		}
+		if got, expected := index(string(AppendSI(nil, x, prec)), siPrefixes[:]), scaled(b, 1000, k, len(siPrefixes)-1, prec); got != expected {
+			t.Fatalf("AppendSI(%#x, %d) mismatch: expected %s, got %s", x, prec, expected, got)
+		}
+
+		k = 0
+		if b.BitLen() > 0 {
+			k = (b.BitLen() - 1) / 10
+		}
+		if k >= len(iecPrefixes) {
+			k = len(iecPrefixes) - 1
+		}
+		if got, expected := index(string(AppendIEC(nil, x, prec)), iecPrefixes[:]), scaled(b, 1024, k, len(iecPrefixes)-1, prec); got != expected {
+			t.Fatalf("AppendIEC(%#x, %d) mismatch: expected %s, got %s", x, prec, expected, got)
+		}
+
+		f := new(big.Float).SetPrec(uint(b.BitLen() + 1)).SetInt(b)
+		if got, expected := string(AppendSci(nil, x, prec)), f.Text('e', prec); got != expected {
+			t.Fatalf("AppendSci(%#x, %d) mismatch: expected %s, got %s", x, prec, expected, got)
+		}
+
+		for _, s := range []string{
+			string(AppendSI(nil, x, -1)),
+			string(AppendIEC(nil, x, -1)),
+			string(AppendSci(nil, x, -1)),
+		} {
+			z := make([]uint64, len(x))
+			if err := ParseHuman(z, s); err != nil || toBig(z).Cmp(b) != 0 {
+				t.Fatalf("ParseHuman(%q) mismatch: expected %#x, got %#x, %v", s, x, z, err)
+			}
+		}
+	}
+}
+
+// TestParseHuman checks parsing on known vectors and errors.
+func TestParseHuman(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected string
+		err      error
+	}{
+		{"0", "0", nil},
+		{"1234", "1234", nil},
+		{"1.5M", "1500000", nil},
+		{"1.5k", "1500", nil},
+		{"1.5K", "1500", nil},
+		{"3e18", "3000000000000000000", nil},
+		{"3E+18", "3000000000000000000", nil},
+		{"1.2345e+76", "12345" + strings.Repeat("0", 72), nil},
+		{"1500e-2", "15", nil},
+		{"2.5e3k", "2500000", nil},
+		{"1.5Ki", "1536", nil},
+		{"1Yi", "1208925819614629174706176", nil},
+		{"1Q", "1" + strings.Repeat("0", 30), nil},
+		{"7.", "7", nil},
+		{"1E", "1000000000000000000", nil},
+		{"0e-5", "0", nil},
+		{"0.000", "0", nil},
+		{"1000e-3", "1", nil},
+		{"0.5Ki", "512", nil},
+		{"0.0009765625Ki", "1", nil},
+		{"1" + strings.Repeat("0", 100) + ".5Ki", "", strconv.ErrRange},
+		{"1.2345k", "", errHumanFraction},
+		{"1e-1", "", errHumanFraction},
+		{"1.1Ki", "", errHumanFraction},
+		{"1e-30Yi", "", errHumanFraction},
+		{"1.5e-20Yi", "", errHumanFraction},
+		{"1e78", "", strconv.ErrRange},
+		{"116Q", "", nil},
+		{"", "", strconv.ErrSyntax},
+		{".5", "", strconv.ErrSyntax},
+		{"-1", "", strconv.ErrSyntax},
+		{"+1", "", strconv.ErrSyntax},
+		{"1.5x", "", strconv.ErrSyntax},
+		{"1.5 M", "", strconv.ErrSyntax},
+		{"1e", "", strconv.ErrSyntax},
+		{"1e+", "", strconv.ErrSyntax},
+		{"1KI", "", strconv.ErrSyntax},
+		{"1m", "", strconv.ErrSyntax},
+		{"1Mk", "", strconv.ErrSyntax},
+		{"1e1001", "", strconv.ErrSyntax},
+		{strings.Repeat("1", 1001), "", strconv.ErrSyntax},
+	}
+	for _, tt := range tests {
+		z := make([]uint64, 4)
+		err := ParseHuman(z, tt.s)
+		if tt.err == nil && tt.expected == "" {
+			tt.expected = new(big.Int).Mul(big.NewInt(116), new(big.Int).Exp(big.NewInt(10), big.NewInt(30), nil)).String()
+		}
+		if tt.err != nil {
+			ne, ok := err.(*strconv.NumError)
+			if !ok || ne.Err != tt.err || ne.Func != "ParseHuman" || ne.Num != tt.s {
+				t.Errorf("ParseHuman(%q) should fail with %v, got %v", tt.s, tt.err, err)
+			}
+			continue
+		}
+		if err != nil || toBig(z).String() != tt.expected {
+			t.Errorf("ParseHuman(%q) mismatch: expected %s, got %s, %v", tt.s, tt.expected, toBig(z), err)
+		}
+	}
+}
+
+// TestParseHumanAllocs checks ParseHuman does not allocate.
+func TestParseHumanAllocs(t *testing.T) {
+	var z [4]uint64
+	if n := testing.AllocsPerRun(100, func() {
+		_ = ParseHuman(z[:], "1.2345e+76")
+		_ = ParseHuman(z[:], "1.5Ki")
+	}); n != 0 {
+		t.Errorf("ParseHuman should not allocate, got %v allocs", n)
+	}
+}
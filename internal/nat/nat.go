@@ -0,0 +1,53 @@
+// Package nat implements conversions of natural numbers shared by all widths.
+//
+// A number is represented as a slice of 64-bit words in little-endian order,
+// i.e. x[0] is the least significant word, exactly as returned by the Words()
+// method of every fixed-width type. Slices are never retained or modified.
+package nat
+
+import "math/bits"
+
+// MaxWords is the maximum number of words supported, enough for 1024 bits.
+const MaxWords = 16
+
+// norm returns x without most significant zero words.
+func norm(x []uint64) []uint64 {
+	n := len(x)
+	for n > 0 && x[n-1] == 0 {
+		n--
+	}
+	return x[:n]
+}
+
+// bitLen returns the minimum number of bits required to represent x.
+func bitLen(x []uint64) int {
+	x = norm(x)
+	if len(x) == 0 {
+		return 0
+	}
+	return (len(x)-1)*64 + bits.Len64(x[len(x)-1])
+}
+
+// bigBase is the largest power of a base that fits in a uint64.
+type bigBase struct {
+	b uint64 // base^n
+	n int
+}
+
+// bigBases are precomputed for all bases in range [2, 255],
+// bases above 36 are used by custom alphabets only.
+var bigBases [256]bigBase
+
+func init() {
+	for base := uint64(2); base < uint64(len(bigBases)); base++ {
+		bb := bigBase{b: base, n: 1}
+		for {
+			hi, lo := bits.Mul64(bb.b, base)
+			if hi != 0 {
+				break
+			}
+			bb.b, bb.n = lo, bb.n+1
+		}
+		bigBases[base] = bb
+	}
+}
@@ -0,0 +1,114 @@
+package nat
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+)
+
+// Ordered key decoding errors.
+var (
+	errKeyTruncated    = errors.New("orderedkey: data truncated")
+	errKeyNonCanonical = errors.New("orderedkey: non-canonical encoding")
+	errKeyOverflow     = errors.New("orderedkey: integer overflow")
+)
+
+// AppendOrderedKey appends x to dst as a key whose bytewise order matches
+// the numeric order: the count of significant bytes followed by these
+// bytes in big-endian order, e.g. 0x1234 is 02 12 34 and zero is 00.
+// The keys are prefix-free, so they can be followed by other key parts.
+// If desc is set all bytes are complemented and the order is reversed.
+func AppendOrderedKey(dst []byte, x []uint64, desc bool) []byte {
+	x = norm(x)
+	n := (bitLen(x) + 7) / 8
+	start := len(dst)
+	dst = append(dst, byte(n))
+	if len(x) > 0 {
+		top := x[len(x)-1]
+		for i := (bits.Len64(top)+7)/8 - 1; i >= 0; i-- {
+			dst = append(dst, byte(top>>(8*i)))
+		}
+		dst = AppendBigEndian(dst, x[:len(x)-1])
+	}
+	if desc {
+		complement(dst[start:])
+	}
+	return dst
+}
+
+// DecodeOrderedKey decodes the key of AppendOrderedKey from the front of b
+// into z and returns the rest of b. Leading zero bytes and values
+// overflowing len(z) words are rejected.
+func DecodeOrderedKey(z []uint64, b []byte, desc bool) (rest []byte, err error) {
+	for i := range z {
+		z[i] = 0
+	}
+	if len(b) == 0 {
+		return b, errKeyTruncated
+	}
+	flip := byte(0)
+	if desc {
+		flip = 0xff
+	}
+	n := int(b[0] ^ flip)
+	if n > len(b)-1 {
+		return b, errKeyTruncated
+	}
+	if n > 0 && b[1]^flip == 0 {
+		return b, errKeyNonCanonical
+	}
+	if n > 8*len(z) {
+		return b, errKeyOverflow
+	}
+	for i, c := range b[1 : 1+n] {
+		k := n - 1 - i // byte index from the least significant
+		z[k/8] |= uint64(c^flip) << (8 * (k % 8))
+	}
+	return b[1+n:], nil
+}
+
+// AppendOrderedKeySigned appends two's complement x to dst as a fixed-size
+// key of 8*len(x) big-endian bytes with the sign bit flipped, so that
+// the bytewise order matches the signed order. If desc is set all bytes
+// are complemented and the order is reversed.
+func AppendOrderedKeySigned(dst []byte, x []uint64, desc bool) []byte {
+	start := len(dst)
+	dst = AppendBigEndian(dst, x)
+	if len(dst) > start {
+		dst[start] ^= 0x80
+	}
+	if desc {
+		complement(dst[start:])
+	}
+	return dst
+}
+
+// DecodeOrderedKeySigned decodes the key of AppendOrderedKeySigned
+// from the front of b into z and returns the rest of b.
+func DecodeOrderedKeySigned(z []uint64, b []byte, desc bool) (rest []byte, err error) {
+	n := 8 * len(z)
+	if len(b) < n {
+		for i := range z {
+			z[i] = 0
+		}
+		return b, errKeyTruncated
+	}
+	flip := uint64(0)
+	if desc {
+		flip = ^uint64(0)
+	}
+	for i := range z {
+		z[i] = binary.BigEndian.Uint64(b[n-8*(i+1):]) ^ flip
+	}
+	if len(z) > 0 {
+		z[len(z)-1] ^= 1 << 63
+	}
+	return b[n:], nil
+}
+
+// complement inverts all bits of b.
+func complement(b []byte) {
+	for i := range b {
+		b[i] = ^b[i]
+	}
+}
@@ -0,0 +1,130 @@
+package nat
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// TestOrderedKey checks ordered key encoding on known vectors and errors.
+func TestOrderedKey(t *testing.T) {
+	tests := []struct {
+		x   []uint64
+		key string
+	}{
+		{[]uint64{0, 0}, "00"},
+		{[]uint64{1}, "0101"},
+		{[]uint64{0xff}, "01ff"},
+		{[]uint64{0x1234}, "021234"},
+		{[]uint64{^uint64(0)}, "08ffffffffffffffff"},
+		{[]uint64{0, 1}, "09010000000000000000"},
+	}
+	for _, tt := range tests {
+		for _, desc := range []bool{false, true} {
+			expected, _ := hex.DecodeString(tt.key)
+			if desc {
+				complement(expected)
+			}
+			key := AppendOrderedKey([]byte{0x05}, tt.x, desc)
+			if !bytes.Equal(key[1:], expected) {
+				t.Errorf("AppendOrderedKey(%#x, %t) mismatch:\n\t(-) expected %x\n\t(+)   actual %x", tt.x, desc, expected, key[1:])
+			}
+
+			z := make([]uint64, len(tt.x))
+			rest, err := DecodeOrderedKey(z, append(key[1:], 0x05), desc)
+			if err != nil || !bytes.Equal(rest, []byte{0x05}) || toBig(z).Cmp(toBig(tt.x)) != 0 {
+				t.Errorf("DecodeOrderedKey(%x, %t) mismatch: %#x, %v, rest %x", expected, desc, z, err, rest)
+			}
+		}
+	}
+
+	invalid := []struct {
+		key string
+		err error
+	}{
+		{"", errKeyTruncated},
+		{"01", errKeyTruncated},
+		{"0301ff", errKeyTruncated},
+		{"0100", errKeyNonCanonical},
+		{"020001", errKeyNonCanonical},
+		{"09010000000000000000", errKeyOverflow},
+	}
+	for _, tt := range invalid {
+		for _, desc := range []bool{false, true} {
+			b, _ := hex.DecodeString(tt.key)
+			if desc {
+				complement(b)
+			}
+			z := make([]uint64, 1)
+			if _, err := DecodeOrderedKey(z, b, desc); err != tt.err {
+				t.Errorf("DecodeOrderedKey(%x, %t) should fail with %v, got %v", b, desc, tt.err, err)
+			}
+		}
+	}
+}
+
+// TestOrderedKeyRand checks bytewise order of keys matches the numeric order.
+func TestOrderedKeyRand(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, signed := range []bool{false, true} {
+		for _, desc := range []bool{false, true} {
+			values := make([][]uint64, 2000)
+			keys := make([][]byte, len(values))
+			for i := range values {
+				values[i] = randWords(r)
+				values[i] = append(values[i], make([]uint64, 16-len(values[i]))...)
+				if i%3 == 0 {
+					values[i] = append([]uint64(nil), values[i-i%6]...) // duplicates
+				}
+				if signed {
+					keys[i] = AppendOrderedKeySigned(nil, values[i], desc)
+				} else {
+					keys[i] = AppendOrderedKey(nil, values[i], desc)
+				}
+
+				z := make([]uint64, 16)
+				var rest []byte
+				var err error
+				if signed {
+					rest, err = DecodeOrderedKeySigned(z, keys[i], desc)
+				} else {
+					rest, err = DecodeOrderedKey(z, keys[i], desc)
+				}
+				if err != nil || len(rest) != 0 || toBig(z).Cmp(toBig(values[i])) != 0 {
+					t.Fatalf("decode(%x, %t) mismatch: %#x, %v", keys[i], desc, z, err)
+				}
+			}
+
+			// two's complement value of 1024-bit words
+			value := func(x []uint64) *big.Int {
+				v := toBig(x)
+				if signed && x[15]>>63 == 1 {
+					v.Sub(v, new(big.Int).Lsh(big.NewInt(1), 1024))
+				}
+				return v
+			}
+			index := make([]int, len(values))
+			for i := range index {
+				index[i] = i
+			}
+			sort.Slice(index, func(i, j int) bool { return bytes.Compare(keys[index[i]], keys[index[j]]) < 0 })
+			for i := 1; i < len(index); i++ {
+				c := value(values[index[i-1]]).Cmp(value(values[index[i]]))
+				if desc {
+					c = -c
+				}
+				if c > 0 {
+					t.Fatalf("signed %t, desc %t: %#x sorts before %#x", signed, desc, values[index[i-1]], values[index[i]])
+				}
+			}
+		}
+	}
+
+	z := make([]uint64, 2)
+	if _, err := DecodeOrderedKeySigned(z, make([]byte, 15), false); err != errKeyTruncated {
+		t.Errorf("DecodeOrderedKeySigned should fail with %v, got %v", errKeyTruncated, err)
+	}
+}
@@ -0,0 +1,181 @@
+package nat
+
+import (
+	"errors"
+	"math/bits"
+	"strconv"
+)
+
+// digitValues maps characters to digit values, invalid characters are 255.
+var digitValues = func() (t [256]byte) {
+	for i := range t {
+		t[i] = 255
+	}
+	for i := 0; i < len(lowerDigits); i++ {
+		t[lowerDigits[i]] = byte(i)
+		t[upperDigits[i]] = byte(i)
+	}
+	return t
+}()
+
+// lower returns lower case of ASCII letter.
+func lower(c byte) byte {
+	return c | ('x' - 'X')
+}
+
+// ParseUint interprets s in the given base (0, 2 to 36) and stores the result in z.
+// The words of z are in little-endian order, len(z) limits the range of the result.
+//
+// The semantic is the same as of strconv.ParseUint:
+//   - if base is 0, it is implied by the prefix: 0b or 0B is base 2,
+//     0o, 0O or just 0 is base 8, 0x or 0X is base 16, base 10 otherwise,
+//     underscore characters are permitted as digit separators;
+//   - the error is *strconv.NumError with Func "ParseUint";
+//   - on syntax error z is 0 and error is strconv.ErrSyntax;
+//   - on overflow z is the maximum value and error is strconv.ErrRange.
+//
+// There is no memory allocation unless an error is returned.
+func ParseUint[S ~string | ~[]byte](z []uint64, s S, base int) error {
+	const fn = "ParseUint"
+
+	for i := range z {
+		z[i] = 0
+	}
+	if len(s) == 0 {
+		return syntaxError(fn, s)
+	}
+
+	base0 := base == 0
+	i := 0 // the first digit
+	prev := byte('^')
+	switch {
+	case base0:
+		base = 10
+		if s[0] == '0' {
+			switch {
+			case len(s) >= 3 && lower(s[1]) == 'b':
+				base, i = 2, 2
+			case len(s) >= 3 && lower(s[1]) == 'o':
+				base, i = 8, 2
+			case len(s) >= 3 && lower(s[1]) == 'x':
+				base, i = 16, 2
+			default:
+				base, i = 8, 1
+			}
+			prev = '0' // base prefix is a digit for underscores
+		}
+	case base < 2 || base > 36:
+		return &strconv.NumError{Func: fn, Num: string(s), Err: errors.New("invalid base " + strconv.Itoa(base))}
+	}
+
+	// long decimal numbers are parsed by divide-and-conquer
+	if base == 10 && len(s)-i > leafDigits && isDecimal(s[i:]) {
+		if !parseDecimal(z, s[i:]) {
+			return rangeError(fn, z, s)
+		}
+		return nil
+	}
+
+	// digits are accumulated in a word and added in chunks
+	b := uint64(base)
+	bb := bigBases[base]
+	var acc uint64 // accumulated chunk
+	var n int      // number of digits in acc
+	var used int   // number of words of z in use
+	var ok bool
+	for ; i < len(s); i++ {
+		c := s[i]
+		if c == '_' && base0 {
+			if prev != '0' {
+				return syntaxError(fn, s) // underscore must follow digit
+			}
+			prev = '_'
+			continue
+		}
+		d := uint64(digitValues[c])
+		if d >= b {
+			return syntaxError(fn, s)
+		}
+		prev = '0'
+
+		acc = acc*b + d
+		if n++; n == bb.n {
+			if used, ok = mulAddWord(z, used, bb.b, acc); !ok {
+				return rangeError(fn, z, s)
+			}
+			acc, n = 0, 0
+		}
+	}
+	if prev != '0' {
+		return syntaxError(fn, s) // underscore must be followed by digit
+	}
+	if n > 0 {
+		m := b
+		for ; n > 1; n-- {
+			m *= b
+		}
+		if _, ok = mulAddWord(z, used, m, acc); !ok {
+			return rangeError(fn, z, s)
+		}
+	}
+	return nil
+}
+
+// isDecimal reports whether s consists of decimal digits only.
+func isDecimal[S ~string | ~[]byte](s S) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// mulAddWord sets z = z*m + a, where only n least significant words of z
+// are in use. It returns the new number of words in use and reports whether
+// there is no overflow.
+func mulAddWord(z []uint64, n int, m, a uint64) (int, bool) {
+	carry := a
+	for i := range z[:n] {
+		hi, lo := bits.Mul64(z[i], m)
+		var c uint64
+		z[i], c = bits.Add64(lo, carry, 0)
+		carry = hi + c
+	}
+	if carry != 0 {
+		if n == len(z) {
+			return n, false
+		}
+		z[n] = carry
+		n++
+	}
+	return n, true
+}
+
+// syntaxError returns strconv.ErrSyntax error.
+func syntaxError[S ~string | ~[]byte](fn string, s S) error {
+	return &strconv.NumError{Func: fn, Num: string(s), Err: strconv.ErrSyntax}
+}
+
+// rangeError sets z to the maximum value and returns strconv.ErrRange error.
+func rangeError[S ~string | ~[]byte](fn string, z []uint64, s S) error {
+	for i := range z {
+		z[i] = ^uint64(0)
+	}
+	return &strconv.NumError{Func: fn, Num: string(s), Err: strconv.ErrRange}
+}
+
+// ParseJSON interprets JSON value data and stores the result in z.
+// The value is either a number or a string containing decimal digits
+// or "0x" prefixed hex digits. Exponents, signs and fractions are
+// not permitted. The error is the same as of ParseUint.
+func ParseJSON(z []uint64, data []byte) error {
+	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' {
+		s := data[1 : len(data)-1]
+		if len(s) > 2 && s[0] == '0' && lower(s[1]) == 'x' {
+			return ParseUint(z, s[2:], 16)
+		}
+		return ParseUint(z, s, 10)
+	}
+	return ParseUint(z, data, 10)
+}
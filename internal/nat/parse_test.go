@@ -0,0 +1,98 @@
+package nat
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+// fromBig converts big.Int to n words, ok is false on overflow.
+func fromBig(b *big.Int, n int) ([]uint64, bool) {
+	if b.BitLen() > n*64 {
+		return nil, false
+	}
+	z := make([]uint64, n)
+	for i := range z {
+		z[i] = new(big.Int).Rsh(b, uint(i*64)).Uint64()
+	}
+	return z, true
+}
+
+// TestParseUint64 checks ParseUint is the same as strconv.ParseUint for one word.
+func TestParseUint64(t *testing.T) {
+	inputs := []string{
+		"", "0", "00", "1", "-1", "+1", " 1", "1 ", "a", "z", "Z",
+		"18446744073709551615", "18446744073709551616", "99999999999999999999999",
+		"0x", "0x0", "0xffffffffffffffff", "0x10000000000000000", "0X1F", "0xg",
+		"0b", "0b101", "0B2", "0o", "0o777", "0O8", "0777", "08", "09x",
+		"1_000", "_1000", "1000_", "1__000", "0x_1", "0_x1", "0b_1_0", "0_7", "0_",
+		"1ffffffffffffffff", "zzzzzzzzzzzzz", "3w5e11264sgsg", "3w5e11264sgsh",
+	}
+	for _, s := range inputs {
+		for _, base := range []int{0, 2, 8, 10, 16, 36, 1, 37} {
+			expected, expectedErr := strconv.ParseUint(s, base, 64)
+			var z [1]uint64
+			err := ParseUint(z[:], s, base)
+			if z[0] != expected || (err == nil) != (expectedErr == nil) {
+				t.Fatalf("ParseUint(%q, %d) mismatch:\n\t(-) expected %d, %v\n\t(+)   actual %d, %v", s, base, expected, expectedErr, z[0], err)
+			}
+			if err != nil && err.Error() != expectedErr.Error() {
+				t.Fatalf("ParseUint(%q, %d) error mismatch:\n\t(-) expected %v\n\t(+)   actual %v", s, base, expectedErr, err)
+			}
+		}
+	}
+}
+
+// TestParseUint checks ParseUint on multiple words against big.Int.
+func TestParseUint(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		x := randWords(r)
+		b := toBig(x)
+		base := 2 + r.Intn(35)
+		s := b.Text(base)
+		for n := 1; n <= MaxWords; n++ {
+			expected, ok := fromBig(b, n)
+			z := make([]uint64, n)
+			err := ParseUint(z, s, base)
+			if !ok {
+				if !errors.Is(err, strconv.ErrRange) {
+					t.Fatalf("ParseUint(%q, %d) into %d words should fail with range error, got %v", s, base, n, err)
+				}
+				for _, w := range z {
+					if w != math.MaxUint64 {
+						t.Fatalf("ParseUint(%q, %d) into %d words should be max on overflow, got %#x", s, base, n, z)
+					}
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatalf("ParseUint(%q, %d) into %d words failed: %v", s, base, n, err)
+			}
+			for j := range z {
+				if z[j] != expected[j] {
+					t.Fatalf("ParseUint(%q, %d) into %d words mismatch:\n\t(-) expected %#x\n\t(+)   actual %#x", s, base, n, expected, z)
+				}
+			}
+			if got := string(AppendDigits(nil, z, base)); got != s {
+				t.Fatalf("AppendDigits(%#x, %d) mismatch:\n\t(-) expected %q\n\t(+)   actual %q", z, base, s, got)
+			}
+		}
+	}
+}
+
+// TestParseUintAllocs checks ParseUint does not allocate.
+func TestParseUintAllocs(t *testing.T) {
+	var z [4]uint64
+	s := "0x_ffff_ffff_ffff_ffff_ffff_ffff_ffff_ffff_ffff_ffff_ffff_ffff_ffff_ffff_ffff_ffff"
+	b := []byte("115792089237316195423570985008687907853269984665640564039457584007913129639935")
+	if n := testing.AllocsPerRun(100, func() {
+		_ = ParseUint(z[:], s, 0)
+		_ = ParseUint(z[:], b, 10)
+	}); n != 0 {
+		t.Errorf("ParseUint should not allocate, got %v allocs", n)
+	}
+}
@@ -0,0 +1,112 @@
+package nat
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+)
+
+// RLP decoding errors, the same messages as of go-ethereum's rlp package.
+var (
+	errRLPExpectedString = errors.New("rlp: expected input string or byte")
+	errRLPCanonInt       = errors.New("rlp: non-canonical integer (leading zero bytes)")
+	errRLPCanonSize      = errors.New("rlp: non-canonical size information")
+	errRLPValueTooLarge  = errors.New("rlp: value size exceeds available input length")
+	errRLPUintOverflow   = errors.New("rlp: uint overflow")
+)
+
+// AppendRLP appends the canonical RLP encoding of x to dst: the string
+// of minimal big-endian bytes, zero is encoded as the empty string.
+func AppendRLP(dst []byte, x []uint64) []byte {
+	x = norm(x)
+	switch {
+	case len(x) == 0:
+		return append(dst, 0x80) // empty string
+	case len(x) == 1 && x[0] < 0x80:
+		return append(dst, byte(x[0])) // single byte is its own encoding
+	}
+
+	n := (bitLen(x) + 7) / 8
+	if n <= 55 {
+		dst = append(dst, 0x80+byte(n))
+	} else {
+		m := (bits.Len(uint(n)) + 7) / 8 // length of length
+		dst = append(dst, 0xb7+byte(m))
+		for i := m - 1; i >= 0; i-- {
+			dst = append(dst, byte(n>>(8*i)))
+		}
+	}
+
+	// the most significant word without leading zeros
+	top := x[len(x)-1]
+	for i := (bits.Len64(top)+7)/8 - 1; i >= 0; i-- {
+		dst = append(dst, byte(top>>(8*i)))
+	}
+	for i := len(x) - 2; i >= 0; i-- {
+		dst = binary.BigEndian.AppendUint64(dst, x[i])
+	}
+	return dst
+}
+
+// DecodeRLP decodes the canonical RLP encoded integer from the front of b
+// into z and returns the rest of b. Leading zero bytes, non-minimal size
+// and values overflowing len(z) words are rejected.
+func DecodeRLP(z []uint64, b []byte) (rest []byte, err error) {
+	for i := range z {
+		z[i] = 0
+	}
+	if len(b) == 0 {
+		return b, errRLPValueTooLarge
+	}
+
+	var content []byte
+	switch p := b[0]; {
+	case p < 0x80:
+		if p == 0 {
+			return b, errRLPCanonInt // zero must be the empty string
+		}
+		content, rest = b[:1], b[1:]
+	case p <= 0xb7:
+		n := int(p - 0x80)
+		if len(b) < 1+n {
+			return b, errRLPValueTooLarge
+		}
+		if n == 1 && b[1] < 0x80 {
+			return b, errRLPCanonSize // single byte must be its own encoding
+		}
+		content, rest = b[1:1+n], b[1+n:]
+	case p <= 0xbf:
+		m := int(p - 0xb7)
+		if len(b) < 1+m {
+			return b, errRLPValueTooLarge
+		}
+		if b[1] == 0 {
+			return b, errRLPCanonSize // leading zeros in length
+		}
+		var n uint64
+		for _, c := range b[1 : 1+m] {
+			n = n<<8 | uint64(c)
+		}
+		if n <= 55 {
+			return b, errRLPCanonSize // must be short string
+		}
+		if n > uint64(len(b)-1-m) {
+			return b, errRLPValueTooLarge
+		}
+		content, rest = b[1+m:1+m+int(n)], b[1+m+int(n):]
+	default:
+		return b, errRLPExpectedString
+	}
+
+	if len(content) > 0 && content[0] == 0 {
+		return b, errRLPCanonInt
+	}
+	if len(content) > 8*len(z) {
+		return b, errRLPUintOverflow
+	}
+	for i, c := range content {
+		k := len(content) - 1 - i // byte index from the least significant
+		z[k/8] |= uint64(c) << (8 * (k % 8))
+	}
+	return rest, nil
+}
@@ -0,0 +1,85 @@
+package nat
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// TestRLP checks RLP encoding on known vectors.
+func TestRLP(t *testing.T) {
+	tests := []struct {
+		x   []uint64
+		rlp string
+	}{
+		{[]uint64{0, 0}, "80"},
+		{[]uint64{1}, "01"},
+		{[]uint64{0x7f}, "7f"},
+		{[]uint64{0x80}, "8180"},
+		{[]uint64{0x400}, "820400"},
+		{[]uint64{0xffffffffffffffff}, "88ffffffffffffffff"},
+		{[]uint64{0, 1}, "89010000000000000000"},
+		{[]uint64{^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0)}, "a0" + strings.Repeat("ff", 32)},
+		{[]uint64{0, 0, 0, 0, 0, 0, 0, 1 << 56}, "b840" + "01" + strings.Repeat("00", 63)},
+	}
+	for _, tt := range tests {
+		enc := AppendRLP([]byte{0xc0}, tt.x)
+		if got := hex.EncodeToString(enc[1:]); got != tt.rlp {
+			t.Errorf("AppendRLP(%#x) mismatch:\n\t(-) expected %s\n\t(+)   actual %s", tt.x, tt.rlp, got)
+		}
+
+		z := make([]uint64, len(tt.x))
+		rest, err := DecodeRLP(z, append(enc[1:], 0xc0))
+		if err != nil || !bytes.Equal(rest, []byte{0xc0}) {
+			t.Fatalf("DecodeRLP(%s) failed: %v, rest %x", tt.rlp, err, rest)
+		}
+		if toBig(z).Cmp(toBig(tt.x)) != 0 {
+			t.Errorf("DecodeRLP(%s) mismatch: %#x", tt.rlp, z)
+		}
+	}
+}
+
+// TestRLPErrors checks RLP decoding rejects non-canonical and invalid inputs.
+func TestRLPErrors(t *testing.T) {
+	tests := []struct {
+		rlp string
+		err error
+	}{
+		{"", errRLPValueTooLarge},
+		{"00", errRLPCanonInt},
+		{"8100", errRLPCanonSize},
+		{"8101", errRLPCanonSize},
+		{"820001", errRLPCanonInt},
+		{"8201", errRLPValueTooLarge},
+		{"b801ff", errRLPCanonSize},
+		{"b90001ff", errRLPCanonSize},
+		{"b840ff", errRLPValueTooLarge},
+		{"c0", errRLPExpectedString},
+		{"a1" + strings.Repeat("ff", 33), errRLPUintOverflow},
+	}
+	for _, tt := range tests {
+		b, _ := hex.DecodeString(tt.rlp)
+		var z [4]uint64
+		if _, err := DecodeRLP(z[:], b); err != tt.err {
+			t.Errorf("DecodeRLP(%s) should fail with %v, got %v", tt.rlp, tt.err, err)
+		}
+	}
+}
+
+// TestRLPRand checks RLP decoding is the inverse of encoding.
+func TestRLPRand(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		x := randWords(r)
+		z := make([]uint64, len(x))
+		enc := AppendRLP(nil, x)
+		if rest, err := DecodeRLP(z, enc); err != nil || len(rest) != 0 {
+			t.Fatalf("DecodeRLP(%x) failed: %v, rest %x", enc, err, rest)
+		}
+		if toBig(z).Cmp(toBig(x)) != 0 {
+			t.Fatalf("DecodeRLP is not the inverse of AppendRLP for %#x, got %#x", x, z)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package nat
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// SortedBlockLen is the number of values per block of sorted sequences.
+const SortedBlockLen = 128
+
+// Sorted sequence header errors.
+var (
+	errSortedTruncated = errors.New("sorted: data truncated")
+	errSortedHeader    = errors.New("sorted: invalid header")
+)
+
+// AppendSortedHeader appends the header of a sorted sequence of count
+// values to dst: uvarint count, uvarint values per block and uvarint
+// byte length of every block.
+func AppendSortedHeader(dst []byte, count, blockLen int, blocks []int) []byte {
+	dst = binary.AppendUvarint(dst, uint64(count))
+	dst = binary.AppendUvarint(dst, uint64(blockLen))
+	for _, n := range blocks {
+		dst = binary.AppendUvarint(dst, uint64(n))
+	}
+	return dst
+}
+
+// ParseSortedHeader parses the header of AppendSortedHeader and returns
+// the number of values, values per block and block boundaries: block k
+// is data[offsets[k]:offsets[k+1]]. The blocks must fill the rest of data,
+// every value takes at least one byte.
+func ParseSortedHeader(data []byte) (count, blockLen int, offsets []int, err error) {
+	pos := 0
+	// uvarint reads the next number, which is at most max
+	uvarint := func(max int) (int, error) {
+		v, n := binary.Uvarint(data[pos:])
+		if n == 0 {
+			return 0, errSortedTruncated
+		}
+		if n < 0 || v > uint64(max) {
+			return 0, errSortedHeader
+		}
+		pos += n
+		return int(v), nil
+	}
+
+	if count, err = uvarint(len(data)); err != nil {
+		return 0, 0, nil, err
+	}
+	if blockLen, err = uvarint(math.MaxInt32); err != nil {
+		return 0, 0, nil, err
+	}
+	if blockLen == 0 {
+		return 0, 0, nil, errSortedHeader
+	}
+	// count + blockLen - 1 may overflow a 32-bit int
+	blocks := 0
+	if count > 0 {
+		blocks = (count-1)/blockLen + 1
+	}
+	offsets = make([]int, blocks+1)
+	for k := 0; k < blocks; k++ {
+		if offsets[k+1], err = uvarint(len(data)); err != nil {
+			return 0, 0, nil, err
+		}
+	}
+
+	// block lengths to offsets after the header
+	offsets[0] = pos
+	for k := 1; k <= blocks; k++ {
+		offsets[k] += offsets[k-1]
+		if offsets[k] > len(data) {
+			return 0, 0, nil, errSortedTruncated
+		}
+	}
+	if offsets[blocks] != len(data) || len(data)-pos < count {
+		return 0, 0, nil, errSortedHeader
+	}
+	return count, blockLen, offsets, nil
+}
@@ -0,0 +1,42 @@
+package nat
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// TestSortedHeader checks sorted sequence header round trip and errors.
+func TestSortedHeader(t *testing.T) {
+	data := AppendSortedHeader(nil, 5, 2, []int{2, 3, 1})
+	data = append(data, 1, 2, 3, 4, 5, 6)
+	count, blockLen, offsets, err := ParseSortedHeader(data)
+	if err != nil || count != 5 || blockLen != 2 {
+		t.Fatalf("ParseSortedHeader(%x) failed: %d, %d, %v", data, count, blockLen, err)
+	}
+	if expected := []int{5, 7, 10, 11}; len(offsets) != len(expected) ||
+		offsets[0] != 5 || offsets[1] != 7 || offsets[2] != 10 || offsets[3] != 11 {
+		t.Errorf("ParseSortedHeader(%x) offsets mismatch: expected %d, got %d", data, expected, offsets)
+	}
+
+	tests := []struct {
+		data string
+		err  error
+	}{
+		{"", errSortedTruncated},
+		{"00", errSortedTruncated},
+		{"0000", errSortedHeader},
+		{"0101", errSortedTruncated},
+		{"010102", errSortedTruncated},
+		{"01010205", errSortedTruncated},
+		{"01010105aa", errSortedHeader},
+		{"010101aa02", errSortedHeader},
+		{"0001ffffffffffffffffffff01", errSortedHeader},
+		{"02ffffffff0701aa", errSortedHeader}, // block length of math.MaxInt32
+	}
+	for _, tt := range tests {
+		b, _ := hex.DecodeString(tt.data)
+		if _, _, _, err := ParseSortedHeader(b); err != tt.err {
+			t.Errorf("ParseSortedHeader(%s) should fail with %v, got %v", tt.data, tt.err, err)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package nat
+
+import (
+	"errors"
+	"fmt"
+)
+
+// SQL scanning errors.
+var (
+	errSQLNull     = errors.New("sql: NULL value")
+	errSQLNegative = errors.New("sql: negative value")
+	errSQLFraction = errors.New("sql: non-integer value")
+)
+
+// ScanSQL converts the database/sql source value into z. It accepts
+// int64 and uint64, decimal string or []byte text, e.g. NUMERIC "123",
+// where zero fraction like "123.000" is allowed. NULL, negative,
+// non-integer and overflowing values are rejected.
+func ScanSQL(z []uint64, src interface{}) error {
+	for i := range z {
+		z[i] = 0
+	}
+	switch v := src.(type) {
+	case nil:
+		return errSQLNull
+	case int64:
+		if v < 0 {
+			return errSQLNegative
+		}
+		z[0] = uint64(v)
+		return nil
+	case uint64:
+		z[0] = v
+		return nil
+	case string:
+		return scanSQLText(z, v)
+	case []byte:
+		return scanSQLText(z, v)
+	}
+	return fmt.Errorf("sql: unsupported type %T", src)
+}
+
+// scanSQLText parses decimal text with optional zero fraction.
+func scanSQLText[S ~string | ~[]byte](z []uint64, s S) error {
+	if len(s) > 0 && s[0] == '-' {
+		return errSQLNegative
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] == '.' {
+			for k := i + 1; k < len(s); k++ {
+				if s[k] != '0' {
+					return errSQLFraction
+				}
+			}
+			s = s[:i]
+			break
+		}
+	}
+	return ParseUint(z, s, 10)
+}
@@ -0,0 +1,43 @@
+package nat
+
+import (
+	"math/big"
+	"testing"
+)
+
+// TestScanSQL checks database/sql source value conversion.
+func TestScanSQL(t *testing.T) {
+	max := new(big.Int).Lsh(big.NewInt(1), 128)
+	max.Sub(max, big.NewInt(1))
+
+	good := []struct {
+		src      interface{}
+		expected string
+	}{
+		{int64(0), "0"},
+		{int64(123), "123"},
+		{uint64(1<<64 - 1), "18446744073709551615"},
+		{"123", "123"},
+		{[]byte("123"), "123"},
+		{"123.000", "123"},
+		{[]byte("0.0"), "0"},
+		{"5.", "5"},
+		{max.String(), max.String()},
+	}
+	for _, tt := range good {
+		z := make([]uint64, 2)
+		if err := ScanSQL(z, tt.src); err != nil || toBig(z).String() != tt.expected {
+			t.Errorf("ScanSQL(%#v) mismatch: %v, %v", tt.src, toBig(z), err)
+		}
+	}
+
+	for _, src := range []interface{}{
+		nil, int64(-1), "-1", []byte("-0"), "1.5", "1.0001", ".", "", "1e3", "0x10", " 1", 1.0, true,
+		new(big.Int).Add(max, big.NewInt(1)).String(),
+	} {
+		z := make([]uint64, 2)
+		if err := ScanSQL(z, src); err == nil {
+			t.Errorf("ScanSQL(%#v) should fail", src)
+		}
+	}
+}
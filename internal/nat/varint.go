@@ -0,0 +1,120 @@
+package nat
+
+import (
+	"errors"
+	"io"
+)
+
+// Varint decoding errors.
+var (
+	errVarintOverflow     = errors.New("varint: value overflows the integer")
+	errVarintNonCanonical = errors.New("varint: non-canonical encoding (trailing zero byte)")
+)
+
+// AppendUvarint appends unsigned LEB128 encoding of x to dst:
+// 7 bits per byte starting from the least significant ones,
+// the high bit of every byte but the last one is set.
+func AppendUvarint(dst []byte, x []uint64) []byte {
+	x = norm(x)
+	if len(x) == 0 {
+		return append(dst, 0)
+	}
+	n := (bitLen(x) + 6) / 7
+	for i := 0; i < n; i++ {
+		c := byte(chunk7(x, 7*i))
+		if i+1 < n {
+			c |= 0x80
+		}
+		dst = append(dst, c)
+	}
+	return dst
+}
+
+// chunk7 returns 7 bits of x starting from bit position s.
+func chunk7(x []uint64, s int) uint64 {
+	k, off := s/64, uint(s%64)
+	c := x[k] >> off
+	if off > 64-7 && k+1 < len(x) {
+		c |= x[k+1] << (64 - off)
+	}
+	return c & 0x7f
+}
+
+// Uvarint decodes unsigned LEB128 encoded integer from the front of b
+// into z and returns the number of bytes read. Truncated input returns
+// io.ErrUnexpectedEOF (io.EOF for empty input), values overflowing len(z)
+// words and trailing zero bytes (non-minimal encodings) are rejected.
+func Uvarint(z []uint64, b []byte) (int, error) {
+	var d varintDecoder
+	d.reset(z)
+	for i, c := range b {
+		if done, err := d.next(c); err != nil || done {
+			return i + 1, err
+		}
+	}
+	if len(b) == 0 {
+		return 0, io.EOF
+	}
+	return len(b), io.ErrUnexpectedEOF
+}
+
+// ReadUvarint reads unsigned LEB128 encoded integer from r into z.
+// The errors are the same as of Uvarint, other read errors are returned as is.
+func ReadUvarint(z []uint64, r io.ByteReader) error {
+	var d varintDecoder
+	d.reset(z)
+	for i := 0; ; i++ {
+		c, err := r.ReadByte()
+		if err != nil {
+			if err == io.EOF && i > 0 {
+				err = io.ErrUnexpectedEOF
+			}
+			return err
+		}
+		if done, err := d.next(c); err != nil || done {
+			return err
+		}
+	}
+}
+
+// varintDecoder accumulates 7-bit groups into z.
+type varintDecoder struct {
+	z []uint64
+	s int // bit position of the next group
+}
+
+// reset starts decoding into z.
+func (d *varintDecoder) reset(z []uint64) {
+	for i := range z {
+		z[i] = 0
+	}
+	d.z, d.s = z, 0
+}
+
+// next consumes byte c and reports whether it is the last one.
+func (d *varintDecoder) next(c byte) (bool, error) {
+	v := uint64(c & 0x7f)
+	k, off := d.s/64, uint(d.s%64)
+	if k >= len(d.z) {
+		if v != 0 || c&0x80 != 0 {
+			return false, errVarintOverflow
+		}
+	} else {
+		d.z[k] |= v << off
+		if spill := v >> (64 - off); spill != 0 { // group crosses the word boundary
+			if k+1 >= len(d.z) {
+				return false, errVarintOverflow
+			}
+			d.z[k+1] |= spill
+		}
+	}
+	d.s += 7
+
+	if c&0x80 != 0 {
+		return false, nil
+	}
+	if c == 0 && d.s > 7 {
+		return true, errVarintNonCanonical
+	}
+	return true, nil
+}
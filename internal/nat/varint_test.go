@@ -0,0 +1,130 @@
+package nat
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// TestUvarint checks LEB128 encoding on known vectors and against encoding/binary.
+func TestUvarint(t *testing.T) {
+	tests := []struct {
+		x   []uint64
+		enc string
+	}{
+		{[]uint64{0, 0}, "00"},
+		{[]uint64{1}, "01"},
+		{[]uint64{0x7f}, "7f"},
+		{[]uint64{0x80}, "8001"},
+		{[]uint64{300}, "ac02"},
+		{[]uint64{^uint64(0)}, "ffffffffffffffffff01"},
+		{[]uint64{0, 1}, "80808080808080808002"},
+		{[]uint64{^uint64(0), ^uint64(0)}, strings.Repeat("ff", 18) + "03"},
+	}
+	for _, tt := range tests {
+		enc := AppendUvarint([]byte{0xee}, tt.x)
+		if got := hex.EncodeToString(enc[1:]); got != tt.enc {
+			t.Errorf("AppendUvarint(%#x) mismatch:\n\t(-) expected %s\n\t(+)   actual %s", tt.x, tt.enc, got)
+		}
+
+		z := make([]uint64, len(tt.x))
+		n, err := Uvarint(z, append(enc[1:], 0xee))
+		if err != nil || n != len(enc)-1 || toBig(z).Cmp(toBig(tt.x)) != 0 {
+			t.Errorf("Uvarint(%s) mismatch: %#x, %d, %v", tt.enc, z, n, err)
+		}
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		v := r.Uint64() >> uint(r.Intn(64))
+		if got, expected := AppendUvarint(nil, []uint64{v}), binary.AppendUvarint(nil, v); !bytes.Equal(got, expected) {
+			t.Fatalf("AppendUvarint(%#x) mismatch:\n\t(-) expected %x\n\t(+)   actual %x", v, expected, got)
+		}
+	}
+}
+
+// TestUvarintErrors checks LEB128 decoding rejects invalid inputs.
+func TestUvarintErrors(t *testing.T) {
+	tests := []struct {
+		enc string
+		n   int // words
+		err error
+	}{
+		{"", 1, io.EOF},
+		{"80", 1, io.ErrUnexpectedEOF},
+		{"ffff", 2, io.ErrUnexpectedEOF},
+		{"8000", 1, errVarintNonCanonical},
+		{"ff00", 1, errVarintNonCanonical},
+		{"ffffffffffffffffff02", 1, errVarintOverflow},       // 65 bits
+		{"ffffffffffffffffff818001", 1, errVarintOverflow},   // too long
+		{"ffffffffffffffffff8000", 1, errVarintNonCanonical}, // padded 64 bits
+		{"80808080808080808080808080808080808004", 2, errVarintOverflow},
+		{"80808080808080808080808080808080808002", 2, nil}, // 2**127
+	}
+	for _, tt := range tests {
+		b, _ := hex.DecodeString(tt.enc)
+		z := make([]uint64, tt.n)
+		if _, err := Uvarint(z, b); err != tt.err {
+			t.Errorf("Uvarint(%s) should fail with %v, got %v", tt.enc, tt.err, err)
+		}
+		if err := ReadUvarint(z, bytes.NewReader(b)); err != tt.err {
+			t.Errorf("ReadUvarint(%s) should fail with %v, got %v", tt.enc, tt.err, err)
+		}
+	}
+}
+
+// TestUvarintRand checks LEB128 encoding against big.Int reference on random values.
+func TestUvarintRand(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var stream bytes.Buffer
+	var values [][]uint64
+	for i := 0; i < 10000; i++ {
+		x := randWords(r)
+
+		// reference: 7 bits per byte
+		var expected []byte
+		b := toBig(x)
+		for mask := big.NewInt(0x7f); ; {
+			c := byte(new(big.Int).And(b, mask).Uint64())
+			b = new(big.Int).Rsh(b, 7)
+			if b.Sign() == 0 {
+				expected = append(expected, c)
+				break
+			}
+			expected = append(expected, c|0x80)
+		}
+		enc := AppendUvarint(nil, x)
+		if !bytes.Equal(enc, expected) {
+			t.Fatalf("AppendUvarint(%#x) mismatch:\n\t(-) expected %x\n\t(+)   actual %x", x, expected, enc)
+		}
+
+		z := make([]uint64, len(x))
+		if n, err := Uvarint(z, enc); err != nil || n != len(enc) || toBig(z).Cmp(toBig(x)) != 0 {
+			t.Fatalf("Uvarint(%x) mismatch: %#x, %d, %v", enc, z, n, err)
+		}
+		if len(norm(x)) > 0 && len(norm(x)) < len(x) {
+			if _, err := Uvarint(z[:len(norm(x))-1], enc); err != errVarintOverflow {
+				t.Fatalf("Uvarint(%x) into fewer words should overflow, got %v", enc, err)
+			}
+		}
+		stream.Write(enc)
+		values = append(values, x)
+	}
+
+	br := bufio.NewReader(&stream)
+	for _, x := range values {
+		z := make([]uint64, len(x))
+		if err := ReadUvarint(z, br); err != nil || toBig(z).Cmp(toBig(x)) != 0 {
+			t.Fatalf("ReadUvarint mismatch: %#x, %v, expected %#x", z, err, x)
+		}
+	}
+	if err := ReadUvarint(make([]uint64, 1), br); err != io.EOF {
+		t.Fatalf("ReadUvarint at the end should fail with EOF, got %v", err)
+	}
+}
@@ -0,0 +1,160 @@
+package bigz_test
+
+import (
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/piliming/bigz"
+	"github.com/piliming/bigz/uint1024"
+	"github.com/piliming/bigz/uint128"
+	"github.com/piliming/bigz/uint256"
+	"github.com/piliming/bigz/uint512"
+)
+
+// digits matches width numbers in method names and signatures.
+var digits = regexp.MustCompile(`[0-9]+`)
+
+// partial methods are not required to be provided by all widths:
+// deprecated methods and protocol specific encodings.
+var partial = map[string]bool{
+	"Rsh2": true, // Uint256 only, deprecated
+
+	// RLP, Uint128 and Uint256 only
+	"AppendRLP": true,
+	"EncodeRLP": true,
+
+	// SSZ, Uint128 and Uint256 only
+	"SizeSSZ":      true,
+	"MarshalSSZ":   true,
+	"MarshalSSZTo": true,
+	"UnmarshalSSZ": true,
+	"HashTreeRoot": true,
+}
+
+// methodSet returns normalized methods of type: name => signature.
+// Width suffixes are stripped from names, so Add64 and Add256 are both "Add#".
+// Signatures are only kept for methods without width suffix.
+func methodSet(typ reflect.Type) map[string]string {
+	out := make(map[string]string)
+	for _, typ := range []reflect.Type{typ, reflect.PointerTo(typ)} {
+		for i := 0; i < typ.NumMethod(); i++ {
+			m := typ.Method(i)
+			if partial[m.Name] {
+				continue
+			}
+			name := digits.ReplaceAllString(m.Name, "#")
+			if _, ok := out[name]; ok {
+				continue
+			}
+			if strings.Contains(name, "#") {
+				out[name] = ""
+				continue
+			}
+			sig := m.Type.String()
+			sig = strings.Replace(sig, "*", "", 1) // receiver
+			out[name] = digits.ReplaceAllString(sig, "#")
+		}
+	}
+	return out
+}
+
+// TestParity ensures all width types provide the same API.
+func TestParity(t *testing.T) {
+	types := []reflect.Type{
+		reflect.TypeOf(bigz.Uint128{}),
+		reflect.TypeOf(bigz.Uint256{}),
+		reflect.TypeOf(bigz.Uint512{}),
+		reflect.TypeOf(bigz.Uint1024{}),
+	}
+
+	sets := make([]map[string]string, len(types))
+	all := make(map[string]bool)
+	for i, typ := range types {
+		sets[i] = methodSet(typ)
+		for name := range sets[i] {
+			all[name] = true
+		}
+	}
+
+	names := make([]string, 0, len(all))
+	for name := range all {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for i, typ := range types {
+			sig, ok := sets[i][name]
+			if !ok {
+				t.Errorf("%s: method %s is missing", typ, name)
+				continue
+			}
+			if expected := sets[0][name]; i > 0 && sig != expected {
+				t.Errorf("%s: method %s signature mismatch:\n\t(-) expected %s\n\t(+)   actual %s", typ, name, expected, sig)
+			}
+		}
+	}
+}
+
+// TestParityFuncs ensures all width packages provide the same functions.
+// Missing function breaks compilation, different signature fails the test.
+func TestParityFuncs(t *testing.T) {
+	funcs := [][]interface{}{
+		{uint128.Zero, uint256.Zero, uint512.Zero, uint1024.Zero},
+		{uint128.One, uint256.One, uint512.One, uint1024.One},
+		{uint128.Max, uint256.Max, uint512.Max, uint1024.Max},
+		{uint128.From64, uint256.From64, uint512.From64, uint1024.From64},
+		{uint128.FromBig, uint256.FromBig, uint512.FromBig, uint1024.FromBig},
+		{uint128.FromBigEx, uint256.FromBigEx, uint512.FromBigEx, uint1024.FromBigEx},
+		{uint128.FromString, uint256.FromString, uint512.FromString, uint1024.FromString},
+		{uint128.FromWords, uint256.FromWords, uint512.FromWords, uint1024.FromWords},
+		{uint128.ParseUint, uint256.ParseUint, uint512.ParseUint, uint1024.ParseUint},
+		{uint128.FormatUint, uint256.FormatUint, uint512.FormatUint, uint1024.FormatUint},
+		{uint128.AppendUint, uint256.AppendUint, uint512.AppendUint, uint1024.AppendUint},
+		{uint128.Add, uint256.Add, uint512.Add, uint1024.Add},
+		{uint128.Sub, uint256.Sub, uint512.Sub, uint1024.Sub},
+		{uint128.Mul, uint256.Mul, uint512.Mul, uint1024.Mul},
+		{uint128.Div, uint256.Div, uint512.Div, uint1024.Div},
+		{uint128.StoreLittleEndian, uint256.StoreLittleEndian, uint512.StoreLittleEndian, uint1024.StoreLittleEndian},
+		{uint128.StoreBigEndian, uint256.StoreBigEndian, uint512.StoreBigEndian, uint1024.StoreBigEndian},
+		{uint128.LoadLittleEndian, uint256.LoadLittleEndian, uint512.LoadLittleEndian, uint1024.LoadLittleEndian},
+		{uint128.LoadBigEndian, uint256.LoadBigEndian, uint512.LoadBigEndian, uint1024.LoadBigEndian},
+		{uint128.StoreLittleEndianChecked, uint256.StoreLittleEndianChecked, uint512.StoreLittleEndianChecked, uint1024.StoreLittleEndianChecked},
+		{uint128.StoreBigEndianChecked, uint256.StoreBigEndianChecked, uint512.StoreBigEndianChecked, uint1024.StoreBigEndianChecked},
+		{uint128.LoadLittleEndianChecked, uint256.LoadLittleEndianChecked, uint512.LoadLittleEndianChecked, uint1024.LoadLittleEndianChecked},
+		{uint128.LoadBigEndianChecked, uint256.LoadBigEndianChecked, uint512.LoadBigEndianChecked, uint1024.LoadBigEndianChecked},
+		{uint128.FromBytes, uint256.FromBytes, uint512.FromBytes, uint1024.FromBytes},
+		{uint128.StoreLittleEndianArray, uint256.StoreLittleEndianArray, uint512.StoreLittleEndianArray, uint1024.StoreLittleEndianArray},
+		{uint128.StoreBigEndianArray, uint256.StoreBigEndianArray, uint512.StoreBigEndianArray, uint1024.StoreBigEndianArray},
+		{uint128.LoadLittleEndianArray, uint256.LoadLittleEndianArray, uint512.LoadLittleEndianArray, uint1024.LoadLittleEndianArray},
+		{uint128.LoadBigEndianArray, uint256.LoadBigEndianArray, uint512.LoadBigEndianArray, uint1024.LoadBigEndianArray},
+		{uint128.DecodeUvarint, uint256.DecodeUvarint, uint512.DecodeUvarint, uint1024.DecodeUvarint},
+		{uint128.ReadUvarint, uint256.ReadUvarint, uint512.ReadUvarint, uint1024.ReadUvarint},
+		{uint128.DecodeCBOR, uint256.DecodeCBOR, uint512.DecodeCBOR, uint1024.DecodeCBOR},
+		{uint128.DecodeDER, uint256.DecodeDER, uint512.DecodeDER, uint1024.DecodeDER},
+		{uint128.DecodeOrderedKey, uint256.DecodeOrderedKey, uint512.DecodeOrderedKey, uint1024.DecodeOrderedKey},
+		{uint128.DecodeOrderedKeyDesc, uint256.DecodeOrderedKeyDesc, uint512.DecodeOrderedKeyDesc, uint1024.DecodeOrderedKeyDesc},
+		{uint128.FromRawValue, uint256.FromRawValue, uint512.FromRawValue, uint1024.FromRawValue},
+		{uint128.EncodeBase, uint256.EncodeBase, uint512.EncodeBase, uint1024.EncodeBase},
+		{uint128.EncodeBasePadded, uint256.EncodeBasePadded, uint512.EncodeBasePadded, uint1024.EncodeBasePadded},
+		{uint128.AppendBase, uint256.AppendBase, uint512.AppendBase, uint1024.AppendBase},
+		{uint128.DecodeBase, uint256.DecodeBase, uint512.DecodeBase, uint1024.DecodeBase},
+		{uint128.FormatGrouped, uint256.FormatGrouped, uint512.FormatGrouped, uint1024.FormatGrouped},
+		{uint128.FormatSI, uint256.FormatSI, uint512.FormatSI, uint1024.FormatSI},
+		{uint128.FormatIEC, uint256.FormatIEC, uint512.FormatIEC, uint1024.FormatIEC},
+		{uint128.FormatSci, uint256.FormatSci, uint512.FormatSci, uint1024.FormatSci},
+		{uint128.ParseHuman, uint256.ParseHuman, uint512.ParseHuman, uint1024.ParseHuman},
+	}
+
+	for _, ff := range funcs {
+		expected := digits.ReplaceAllString(reflect.TypeOf(ff[0]).String(), "#")
+		for _, f := range ff[1:] {
+			if sig := digits.ReplaceAllString(reflect.TypeOf(f).String(), "#"); sig != expected {
+				t.Errorf("function signature mismatch:\n\t(-) expected %s\n\t(+)   actual %s", expected, sig)
+			}
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package uint1024
+
+import (
+	"math/big"
+	"testing"
+)
+
+func BenchmarkString(b *testing.B) {
+	const K = 1024 // should be power of 2
+	xx := rand1024slice(K)
+	bb := make([]*big.Int, K)
+	for i, x := range xx {
+		bb[i] = x.Big()
+	}
+
+	b.Run("String_1024", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = xx[i%K].String()
+		}
+	})
+
+	b.Run("String_big", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = bb[i%K].String()
+		}
+	})
+}
+
+func BenchmarkParse(b *testing.B) {
+	const K = 1024 // should be power of 2
+	ss := make([]string, K)
+	for i, x := range rand1024slice(K) {
+		ss[i] = x.String()
+	}
+
+	b.Run("Parse_1024", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = ParseUint(ss[i%K], 10)
+		}
+	})
+
+	b.Run("Parse_big", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = new(big.Int).SetString(ss[i%K], 10)
+		}
+	})
+}
@@ -8,23 +8,25 @@ import (
 	"math/big"
 	"math/bits"
 	"slices"
-	"unsafe"
 )
 
 const bitCount = 1024
 const byteCount = bitCount / 8
 const uint64Count = byteCount / 8
 
+// Zero is the lowest possible Uint1024 value.
 func Zero() Uint1024 {
 	return Uint1024{}
 }
 
 var one = From64(1)
 
+// One is the lowest non-zero Uint1024 value.
 func One() Uint1024 {
 	return one
 }
 
+// Max is the largest possible Uint1024 value.
 func Max() Uint1024 {
 	return Uint1024{
 		Lo: uint512.Max(),
@@ -32,45 +34,63 @@ func Max() Uint1024 {
 	}
 }
 
+// Uint128 is an unsigned 128-bit number alias.
 type Uint128 = uint128.Uint128
+
+// Uint256 is an unsigned 256-bit number alias.
 type Uint256 = uint256.Uint256
+
+// Uint512 is an unsigned 512-bit number alias.
 type Uint512 = uint512.Uint512
 
+// Uint1024 is an unsigned 1024-bit number.
+// All methods are immutable, works just like standard uint64.
 type Uint1024 struct {
-	Lo Uint512
-	Hi Uint512
+	Lo Uint512 // lower 512-bit half
+	Hi Uint512 // upper 512-bit half
 }
 
+// From512 converts 512-bit value v to a Uint1024 value.
+// Upper bits will be zero.
 func From512(v Uint512) Uint1024 {
 	return Uint1024{
 		Lo: v,
 	}
 }
 
+// From256 converts 256-bit value v to a Uint1024 value.
+// Upper bits will be zero.
 func From256(v Uint256) Uint1024 {
 	return Uint1024{
 		Lo: uint512.From256(v),
 	}
 }
 
+// From128 converts 128-bit value v to a Uint1024 value.
+// Upper bits will be zero.
 func From128(v Uint128) Uint1024 {
 	return Uint1024{
 		Lo: uint512.From128(v),
 	}
 }
 
+// From64 converts 64-bit value v to a Uint1024 value.
+// Upper bits will be zero.
 func From64(v uint64) Uint1024 {
 	return Uint1024{
 		Lo: uint512.From64(v),
 	}
 }
 
+// FromBig converts *big.Int to 1024-bit Uint1024 value ignoring overflows.
+// If input integer is nil or negative then return Zero.
+// If input interger overflows 1024-bit then return Max.
 func FromBig(i *big.Int) Uint1024 {
 	u, _ := FromBigEx(i)
 	return u
 }
 
-// FromBigEx converts *big.Int to 256-bit Uint256 value (eXtended version).
+// FromBigEx converts *big.Int to 1024-bit Uint1024 value (eXtended version).
 // Provides ok successful flag as a second return value.
 // If input integer is negative or overflows 1024-bit then ok=false.
 // If input is nil then zero 1024-bit returned.
@@ -81,48 +101,111 @@ func FromBigEx(i *big.Int) (Uint1024, bool) {
 	case i.Sign() < 0:
 		return Zero(), false // value cannot be negative!
 	case i.BitLen() > bitCount:
-		return Max(), false // value overflows 1025-bit!
+		return Max(), false // value overflows 1024-bit!
 	}
 
-	buf := i.Bytes()
-	slices.Reverse(buf)
-
-	arr := [byteCount]byte{}
-
-	copy(arr[:], buf)
-
-	return LoadLittleEndian(arr), true
+	var buf [byteCount]byte
+	return LoadBigEndian(i.FillBytes(buf[:])), true
 }
 
+// Big returns 1024-bit value as a *big.Int.
 func (u Uint1024) Big() *big.Int {
-	buf := [byteCount]byte{}
-
-	copy(buf[:], (*(*[byteCount]byte)(unsafe.Pointer(&u)))[:])
-
+	buf := u.littleEndianBytes()
 	slices.Reverse(buf[:])
 
 	return new(big.Int).SetBytes(buf[:])
 }
 
+// Words returns 1024-bit value as an array of 64-bit limbs
+// in little-endian order, i.e. Words()[0] is the least significant limb.
+func (u Uint1024) Words() [16]uint64 {
+	return [uint64Count]uint64{
+		u.Lo.Lo.Lo.Lo,
+		u.Lo.Lo.Lo.Hi,
+		u.Lo.Lo.Hi.Lo,
+		u.Lo.Lo.Hi.Hi,
+		u.Lo.Hi.Lo.Lo,
+		u.Lo.Hi.Lo.Hi,
+		u.Lo.Hi.Hi.Lo,
+		u.Lo.Hi.Hi.Hi,
+		u.Hi.Lo.Lo.Lo,
+		u.Hi.Lo.Lo.Hi,
+		u.Hi.Lo.Hi.Lo,
+		u.Hi.Lo.Hi.Hi,
+		u.Hi.Hi.Lo.Lo,
+		u.Hi.Hi.Lo.Hi,
+		u.Hi.Hi.Hi.Lo,
+		u.Hi.Hi.Hi.Hi,
+	}
+}
+
+// FromWords converts an array of 64-bit limbs in little-endian order
+// (the least significant limb goes first) to a Uint1024 value.
+func FromWords(w [16]uint64) Uint1024 {
+	return Uint1024{
+		Lo: Uint512{
+			Lo: Uint256{
+				Lo: Uint128{Lo: w[0], Hi: w[1]},
+				Hi: Uint128{Lo: w[2], Hi: w[3]},
+			},
+			Hi: Uint256{
+				Lo: Uint128{Lo: w[4], Hi: w[5]},
+				Hi: Uint128{Lo: w[6], Hi: w[7]},
+			},
+		},
+		Hi: Uint512{
+			Lo: Uint256{
+				Lo: Uint128{Lo: w[8], Hi: w[9]},
+				Hi: Uint128{Lo: w[10], Hi: w[11]},
+			},
+			Hi: Uint256{
+				Lo: Uint128{Lo: w[12], Hi: w[13]},
+				Hi: Uint128{Lo: w[14], Hi: w[15]},
+			},
+		},
+	}
+}
+
+// IsZero returns true if stored 1024-bit value is zero.
 func (u Uint1024) IsZero() bool {
 	return u.Lo.IsZero() && u.Hi.IsZero()
 }
 
+// Equals returns true if two 1024-bit values are equal.
+// Uint1024 values can be compared directly with == operator
+// but use of the Equals method is preferred for consistency.
 func (u Uint1024) Equals(v Uint1024) bool {
 	return u.Lo.Equals(v.Lo) && u.Hi.Equals(v.Hi)
 }
 
+// Equals512 returns true if 1024-bit value equals to a 512-bit value.
 func (u Uint1024) Equals512(v Uint512) bool {
 	return u.Lo.Equals(v) && u.Hi.IsZero()
 }
 
+// Cmp compares two 1024-bit values and returns:
+//
+//	-1 if u <  v
+//	 0 if u == v
+//	+1 if u >  v
 func (u Uint1024) Cmp(v Uint1024) int {
-	if h := u.Hi.Cmp(v.Hi); h != 0 {
-		return h
+	x, y := u.Words(), v.Words()
+	for i := uint64Count - 1; i >= 0; i-- {
+		switch {
+		case x[i] > y[i]:
+			return +1 // u > v
+		case x[i] < y[i]:
+			return -1 // u < v
+		}
 	}
-	return u.Lo.Cmp(v.Lo)
+	return 0 // u == v
 }
 
+// Cmp512 compares 1024-bit and 512-bit values and returns:
+//
+//	-1 if u <  v
+//	 0 if u == v
+//	+1 if u >  v
 func (u Uint1024) Cmp512(v Uint512) int {
 	if !u.Hi.IsZero() {
 		return +1 // u > v
@@ -133,6 +216,7 @@ func (u Uint1024) Cmp512(v Uint512) int {
 ///////////////////////////////////////////////////////////////////////////////
 /// logical operators /////////////////////////////////////////////////////////
 
+// Not returns logical NOT (^u) of 1024-bit value.
 func (u Uint1024) Not() Uint1024 {
 	return Uint1024{
 		Lo: u.Lo.Not(),
@@ -140,6 +224,7 @@ func (u Uint1024) Not() Uint1024 {
 	}
 }
 
+// AndNot returns logical AND NOT (u&^v) of two 1024-bit values.
 func (u Uint1024) AndNot(v Uint1024) Uint1024 {
 	return Uint1024{
 		Lo: u.Lo.AndNot(v.Lo),
@@ -147,6 +232,7 @@ func (u Uint1024) AndNot(v Uint1024) Uint1024 {
 	}
 }
 
+// AndNot512 returns logical AND NOT (u&^v) of 1024-bit and 512-bit values.
 func (u Uint1024) AndNot512(v Uint512) Uint1024 {
 	return Uint1024{
 		Lo: u.Lo.AndNot(v),
@@ -154,6 +240,7 @@ func (u Uint1024) AndNot512(v Uint512) Uint1024 {
 	}
 }
 
+// And returns logical AND (u&v) of two 1024-bit values.
 func (u Uint1024) And(v Uint1024) Uint1024 {
 	return Uint1024{
 		Lo: u.Lo.And(v.Lo),
@@ -161,6 +248,7 @@ func (u Uint1024) And(v Uint1024) Uint1024 {
 	}
 }
 
+// And512 returns logical AND (u&v) of 1024-bit and 512-bit values.
 func (u Uint1024) And512(v Uint512) Uint1024 {
 	return Uint1024{
 		Lo: u.Lo.And(v),
@@ -168,6 +256,7 @@ func (u Uint1024) And512(v Uint512) Uint1024 {
 	}
 }
 
+// Or returns logical OR (u|v) of two 1024-bit values.
 func (u Uint1024) Or(v Uint1024) Uint1024 {
 	return Uint1024{
 		Lo: u.Lo.Or(v.Lo),
@@ -175,6 +264,7 @@ func (u Uint1024) Or(v Uint1024) Uint1024 {
 	}
 }
 
+// Or512 returns logical OR (u|v) of 1024-bit and 512-bit values.
 func (u Uint1024) Or512(v Uint512) Uint1024 {
 	return Uint1024{
 		Lo: u.Lo.Or(v),
@@ -182,6 +272,7 @@ func (u Uint1024) Or512(v Uint512) Uint1024 {
 	}
 }
 
+// Xor returns logical XOR (u^v) of two 1024-bit values.
 func (u Uint1024) Xor(v Uint1024) Uint1024 {
 	return Uint1024{
 		Lo: u.Lo.Xor(v.Lo),
@@ -189,6 +280,7 @@ func (u Uint1024) Xor(v Uint1024) Uint1024 {
 	}
 }
 
+// Xor512 returns logical XOR (u^v) of 1024-bit and 512-bit values.
 func (u Uint1024) Xor512(v Uint512) Uint1024 {
 	return Uint1024{
 		Lo: u.Lo.Xor(v),
@@ -199,38 +291,55 @@ func (u Uint1024) Xor512(v Uint512) Uint1024 {
 ///////////////////////////////////////////////////////////////////////////////
 /// arithmetic operators //////////////////////////////////////////////////////
 
+// Add returns the sum with carry of x, y and carry: sum = x + y + carry.
+// The carry input must be 0 or 1; otherwise the behavior is undefined.
+// The carryOut output is guaranteed to be 0 or 1.
 func Add(x, y Uint1024, carry uint64) (sum Uint1024, carryOut uint64) {
 	sum.Lo, carryOut = uint512.Add(x.Lo, y.Lo, carry)
 	sum.Hi, carryOut = uint512.Add(x.Hi, y.Hi, carryOut)
 	return
 }
 
+// Add returns sum (u+v) of two 1024-bit values.
+// Wrap-around semantic is used here: Max().Add(From64(1)) == Zero()
 func (u Uint1024) Add(v Uint1024) Uint1024 {
 	sum, _ := Add(u, v, 0)
 	return sum
 }
 
+// Add512 returns sum (u+v) of 1024-bit and 512-bit values.
+// Wrap-around semantic is used here.
 func (u Uint1024) Add512(v Uint512) Uint1024 {
 	lo, c0 := uint512.Add(u.Lo, v, 0)
 	return Uint1024{Lo: lo, Hi: u.Hi.Add(uint512.From64(c0))}
 }
 
+// Sub returns the difference of x, y and borrow: diff = x - y - borrow.
+// The borrow input must be 0 or 1; otherwise the behavior is undefined.
+// The borrowOut output is guaranteed to be 0 or 1.
 func Sub(x, y Uint1024, borrow uint64) (diff Uint1024, borrowOut uint64) {
 	diff.Lo, borrowOut = uint512.Sub(x.Lo, y.Lo, borrow)
 	diff.Hi, borrowOut = uint512.Sub(x.Hi, y.Hi, borrowOut)
 	return
 }
 
+// Sub returns difference (u-v) of two 1024-bit values.
+// Wrap-around semantic is used here: Zero().Sub(From64(1)) == Max().
 func (u Uint1024) Sub(v Uint1024) Uint1024 {
 	diff, _ := Sub(u, v, 0)
 	return diff
 }
 
+// Sub512 returns difference (u-v) of 1024-bit and 512-bit values.
+// Wrap-around semantic is used here.
 func (u Uint1024) Sub512(v Uint512) Uint1024 {
 	lo, b0 := uint512.Sub(u.Lo, v, 0)
 	return Uint1024{Lo: lo, Hi: u.Hi.Sub(uint512.From64(b0))}
 }
 
+// Mul returns the 2048-bit product of x and y: (hi, lo) = x * y
+// with the product bits' upper half returned in hi and the lower
+// half returned in lo.
 func Mul(x, y Uint1024) (hi, lo Uint1024) {
 	lo.Hi, lo.Lo = uint512.Mul(x.Lo, y.Lo)
 	hi.Hi, hi.Lo = uint512.Mul(x.Hi, y.Hi)
@@ -246,6 +355,8 @@ func Mul(x, y Uint1024) (hi, lo Uint1024) {
 	return
 }
 
+// Mul returns multiplication (u*v) of two 1024-bit values.
+// Wrap-around semantic is used here: Max().Mul(Max()) == From64(1).
 func (u Uint1024) Mul(v Uint1024) Uint1024 {
 	hi, lo := uint512.Mul(u.Lo, v.Lo)
 	hi = hi.Add(u.Hi.Mul(v.Lo))
@@ -253,6 +364,8 @@ func (u Uint1024) Mul(v Uint1024) Uint1024 {
 	return Uint1024{Lo: lo, Hi: hi}
 }
 
+// Mul512 returns multiplication (u*v) of 1024-bit and 512-bit values.
+// Wrap-around semantic is used here.
 func (u Uint1024) Mul512(v Uint512) Uint1024 {
 	hi, lo := uint512.Mul(u.Lo, v)
 	return Uint1024{
@@ -261,56 +374,67 @@ func (u Uint1024) Mul512(v Uint512) Uint1024 {
 	}
 }
 
+// Div returns division (u/v) of two 1024-bit values.
 func (u Uint1024) Div(v Uint1024) Uint1024 {
 	q, _ := u.QuoRem(v)
 	return q
 }
 
+// Div512 returns division (u/v) of 1024-bit and 512-bit values.
 func (u Uint1024) Div512(v Uint512) Uint1024 {
 	q, _ := u.QuoRem512(v)
 	return q
 }
 
+// Div256 returns division (u/v) of 1024-bit and 256-bit values.
 func (u Uint1024) Div256(v Uint256) Uint1024 {
 	q, _ := u.QuoRem256(v)
 	return q
 }
 
+// Div128 returns division (u/v) of 1024-bit and 128-bit values.
 func (u Uint1024) Div128(v Uint128) Uint1024 {
 	q, _ := u.QuoRem128(v)
 	return q
 }
 
+// Div64 returns division (u/v) of 1024-bit and 64-bit values.
 func (u Uint1024) Div64(v uint64) Uint1024 {
 	q, _ := u.QuoRem64(v)
 	return q
 }
 
+// Mod returns modulo (u%v) of two 1024-bit values.
 func (u Uint1024) Mod(v Uint1024) Uint1024 {
 	_, r := u.QuoRem(v)
 	return r
 }
 
+// Mod512 returns modulo (u%v) of 1024-bit and 512-bit values.
 func (u Uint1024) Mod512(v Uint512) Uint512 {
 	_, r := u.QuoRem512(v)
 	return r
 }
 
+// Mod256 returns modulo (u%v) of 1024-bit and 256-bit values.
 func (u Uint1024) Mod256(v Uint256) Uint256 {
 	_, r := u.QuoRem256(v)
 	return r
 }
 
+// Mod128 returns modulo (u%v) of 1024-bit and 128-bit values.
 func (u Uint1024) Mod128(v Uint128) Uint128 {
 	_, r := u.QuoRem128(v)
 	return r
 }
 
+// Mod64 returns modulo (u%v) of 1024-bit and 64-bit values.
 func (u Uint1024) Mod64(v uint64) uint64 {
 	_, r := u.QuoRem64(v)
 	return r
 }
 
+// QuoRem returns quotient (u/v) and remainder (u%v) of two 1024-bit values.
 func (u Uint1024) QuoRem(v Uint1024) (Uint1024, Uint1024) {
 	if v.Hi.IsZero() {
 		q, r := u.QuoRem512(v.Lo)
@@ -336,6 +460,7 @@ func (u Uint1024) QuoRem(v Uint1024) (Uint1024, Uint1024) {
 	return q, r
 }
 
+// QuoRem512 returns quotient (u/v) and remainder (u%v) of 1024-bit and 512-bit values.
 func (u Uint1024) QuoRem512(v Uint512) (Uint1024, Uint512) {
 	if u.Hi.Cmp(v) < 0 {
 		lo, r := uint512.Div(u.Hi, u.Lo, v)
@@ -348,6 +473,7 @@ func (u Uint1024) QuoRem512(v Uint512) (Uint1024, Uint512) {
 	return Uint1024{Lo: lo, Hi: hi}, r
 }
 
+// QuoRem256 returns quotient (u/v) and remainder (u%v) of 1024-bit and 256-bit values.
 func (u Uint1024) QuoRem256(v Uint256) (q Uint1024, r Uint256) {
 	q.Hi, r = u.Hi.QuoRem256(v)
 
@@ -357,6 +483,7 @@ func (u Uint1024) QuoRem256(v Uint256) (q Uint1024, r Uint256) {
 	return
 }
 
+// QuoRem128 returns quotient (u/v) and remainder (u%v) of 1024-bit and 128-bit values.
 func (u Uint1024) QuoRem128(v Uint128) (q Uint1024, r Uint128) {
 	q.Hi, r = u.Hi.QuoRem128(v)
 
@@ -368,6 +495,7 @@ func (u Uint1024) QuoRem128(v Uint128) (q Uint1024, r Uint128) {
 	return
 }
 
+// QuoRem64 returns quotient (u/v) and remainder (u%v) of 1024-bit and 64-bit values.
 func (u Uint1024) QuoRem64(v uint64) (q Uint1024, r uint64) {
 	q.Hi, r = u.Hi.QuoRem64(v)
 
@@ -386,6 +514,10 @@ func (u Uint1024) QuoRem64(v uint64) (q Uint1024, r uint64) {
 	return
 }
 
+// Div returns the quotient and remainder of (hi, lo) divided by y:
+// quo = (hi, lo)/y, rem = (hi, lo)%y with the dividend bits' upper
+// half in parameter hi and the lower half in parameter lo.
+// Panics if y is less or equal to hi!
 func Div(hi, lo, y Uint1024) (quo, rem Uint1024) {
 	if y.IsZero() {
 		panic(errors.New("integer divide by zero"))
@@ -397,7 +529,7 @@ func Div(hi, lo, y Uint1024) (quo, rem Uint1024) {
 	s := uint(y.LeadingZeros())
 	y = y.Lsh(s)
 
-	un32 := hi.Lsh(s).Or(lo.Rsh(512 - s))
+	un32 := hi.Lsh(s).Or(lo.Rsh(bitCount - s))
 	un10 := lo.Lsh(s)
 	q1, rhat := un32.QuoRem512(y.Hi)
 	r1 := From512(rhat)
@@ -427,331 +559,103 @@ func Div(hi, lo, y Uint1024) (quo, rem Uint1024) {
 			Sub(q0.Mul(y)).Rsh(s)
 }
 
+// Lsh returns left shift (u<<n).
 func (u Uint1024) Lsh(n uint) Uint1024 {
-	if n == 0 {
-		return u
-	}
 	if n >= bitCount {
 		return Zero()
 	}
 
-	if n > 512 {
-		return Uint1024{
-			Lo: Uint512{},
-			Hi: u.Lo.Lsh(n - 512),
-		}
-	}
-
-	if n > 256 {
-		return Uint1024{
-			Lo: Uint512{
-				Lo: Uint256{},
-				Hi: u.Lo.Lo.Lsh(n - 256),
-			},
-			Hi: Uint512{
-				Lo: u.Lo.Hi.Lsh(n - 256).Or(u.Lo.Lo.Rsh(512 - n)),
-				Hi: u.Hi.Lo.Lsh(n - 256).Or(u.Lo.Hi.Rsh(512 - n)),
-			},
-		}
+	w := u.Words()
+	k, m := int(n/64), n%64
+	var r [uint64Count]uint64
+	if m == 0 {
+		copy(r[k:], w[:uint64Count-k])
+		return FromWords(r)
 	}
 
-	if n > 128 {
-		return Uint1024{
-			Lo: Uint512{
-				Lo: Uint256{
-					Lo: Uint128{},
-					Hi: u.Lo.Lo.Lo.Lsh(n - 128),
-				},
-				Hi: Uint256{
-					Lo: u.Lo.Lo.Hi.Lsh(n - 128).Or(u.Lo.Lo.Lo.Rsh(256 - n)),
-					Hi: u.Lo.Hi.Lo.Lsh(n - 128).Or(u.Lo.Lo.Hi.Rsh(256 - n)),
-				},
-			},
-			Hi: Uint512{
-				Lo: Uint256{
-					Lo: u.Lo.Hi.Hi.Lsh(n - 128).Or(u.Lo.Hi.Lo.Rsh(256 - n)),
-					Hi: u.Hi.Lo.Lo.Lsh(n - 128).Or(u.Lo.Hi.Hi.Rsh(256 - n)),
-				},
-				Hi: Uint256{
-					Lo: u.Hi.Lo.Hi.Lsh(n - 128).Or(u.Hi.Lo.Lo.Rsh(256 - n)),
-					Hi: u.Hi.Hi.Lo.Lsh(n - 128).Or(u.Hi.Lo.Hi.Rsh(256 - n)),
-				},
-			},
-		}
-	}
-
-	if n > 64 {
-		return Uint1024{
-			Lo: Uint512{
-				Lo: Uint256{
-					Lo: Uint128{
-						Lo: 0,
-						Hi: u.Lo.Lo.Lo.Lo << (n - 64),
-					},
-					Hi: Uint128{
-						Lo: u.Lo.Lo.Lo.Hi<<(n-64) | u.Lo.Lo.Lo.Lo>>(128-n),
-						Hi: u.Lo.Lo.Hi.Lo<<(n-64) | u.Lo.Lo.Lo.Hi>>(128-n),
-					},
-				},
-				Hi: Uint256{
-					Lo: Uint128{
-						Lo: u.Lo.Lo.Hi.Hi<<(n-64) | u.Lo.Lo.Hi.Lo>>(128-n),
-						Hi: u.Lo.Hi.Lo.Lo<<(n-64) | u.Lo.Lo.Hi.Hi>>(128-n),
-					},
-					Hi: Uint128{
-						Lo: u.Lo.Hi.Lo.Hi<<(n-64) | u.Lo.Hi.Lo.Lo>>(128-n),
-						Hi: u.Lo.Hi.Hi.Lo<<(n-64) | u.Lo.Hi.Lo.Hi>>(128-n),
-					},
-				},
-			},
-			Hi: Uint512{
-				Lo: Uint256{
-					Lo: Uint128{
-						Lo: u.Lo.Hi.Hi.Hi<<(n-64) | u.Lo.Hi.Hi.Lo>>(128-n),
-						Hi: u.Hi.Lo.Lo.Lo<<(n-64) | u.Lo.Hi.Hi.Hi>>(128-n),
-					},
-					Hi: Uint128{
-						Lo: u.Hi.Lo.Lo.Hi<<(n-64) | u.Hi.Lo.Lo.Lo>>(128-n),
-						Hi: u.Hi.Lo.Hi.Lo<<(n-64) | u.Hi.Lo.Lo.Hi>>(128-n),
-					},
-				},
-				Hi: Uint256{
-					Lo: Uint128{
-						Lo: u.Hi.Lo.Hi.Hi<<(n-64) | u.Hi.Lo.Hi.Lo>>(128-n),
-						Hi: u.Hi.Hi.Lo.Lo<<(n-64) | u.Hi.Lo.Hi.Hi>>(128-n),
-					},
-					Hi: Uint128{
-						Lo: u.Hi.Hi.Lo.Hi<<(n-64) | u.Hi.Hi.Lo.Lo>>(128-n),
-						Hi: u.Hi.Hi.Hi.Lo<<(n-64) | u.Hi.Hi.Lo.Hi>>(128-n),
-					},
-				},
-			},
-		}
-	}
-
-	return Uint1024{
-		Lo: Uint512{
-			Lo: Uint256{
-				Lo: Uint128{
-					Lo: u.Lo.Lo.Lo.Lo << n,
-					Hi: u.Lo.Lo.Lo.Hi<<n | u.Lo.Lo.Lo.Lo>>(64-n),
-				},
-				Hi: Uint128{
-					Lo: u.Lo.Lo.Hi.Lo<<n | u.Lo.Lo.Lo.Hi>>(64-n),
-					Hi: u.Lo.Lo.Hi.Hi<<n | u.Lo.Lo.Hi.Lo>>(64-n),
-				},
-			},
-			Hi: Uint256{
-				Lo: Uint128{
-					Lo: u.Lo.Hi.Lo.Lo<<n | u.Lo.Lo.Hi.Hi>>(64-n),
-					Hi: u.Lo.Hi.Lo.Hi<<n | u.Lo.Hi.Lo.Lo>>(64-n),
-				},
-				Hi: Uint128{
-					Lo: u.Lo.Hi.Hi.Lo<<n | u.Lo.Hi.Lo.Hi>>(64-n),
-					Hi: u.Lo.Hi.Hi.Hi<<n | u.Lo.Hi.Hi.Lo>>(64-n),
-				},
-			},
-		},
-		Hi: Uint512{
-			Lo: Uint256{
-				Lo: Uint128{
-					Lo: u.Hi.Lo.Lo.Lo<<n | u.Lo.Hi.Hi.Hi>>(64-n),
-					Hi: u.Hi.Lo.Lo.Hi<<n | u.Hi.Lo.Lo.Lo>>(64-n),
-				},
-				Hi: Uint128{
-					Lo: u.Hi.Lo.Hi.Lo<<n | u.Hi.Lo.Lo.Hi>>(64-n),
-					Hi: u.Hi.Lo.Hi.Hi<<n | u.Hi.Lo.Hi.Lo>>(64-n),
-				},
-			},
-			Hi: Uint256{
-				Lo: Uint128{
-					Lo: u.Hi.Hi.Lo.Lo<<n | u.Hi.Lo.Hi.Hi>>(64-n),
-					Hi: u.Hi.Hi.Lo.Hi<<n | u.Hi.Hi.Lo.Lo>>(64-n),
-				},
-				Hi: Uint128{
-					Lo: u.Hi.Hi.Hi.Lo<<n | u.Hi.Hi.Lo.Hi>>(64-n),
-					Hi: u.Hi.Hi.Hi.Hi<<n | u.Hi.Hi.Hi.Lo>>(64-n),
-				},
-			},
-		},
+	for i := uint64Count - 1; i > k; i-- {
+		r[i] = w[i-k]<<m | w[i-k-1]>>(64-m)
 	}
+	r[k] = w[0] << m
+	return FromWords(r)
 }
 
+// Rsh returns right shift (u>>n).
 func (u Uint1024) Rsh(n uint) Uint1024 {
-	if n == 0 {
-		return u
-	}
 	if n >= bitCount {
 		return Zero()
 	}
 
-	if n > 512 {
-		return Uint1024{
-			Lo: u.Hi.Rsh(n - 512),
-			Hi: Uint512{},
-		}
+	w := u.Words()
+	k, m := int(n/64), n%64
+	var r [uint64Count]uint64
+	if m == 0 {
+		copy(r[:uint64Count-k], w[k:])
+		return FromWords(r)
 	}
 
-	if n > 256 {
-		return Uint1024{
-			Lo: Uint512{
-				Lo: u.Lo.Hi.Rsh(n - 256).Or(u.Hi.Lo.Lsh(512 - n)),
-				Hi: u.Hi.Lo.Rsh(n - 256).Or(u.Hi.Hi.Lsh(512 - n)),
-			},
-			Hi: Uint512{
-				Lo: u.Hi.Hi.Rsh(n - 256),
-				Hi: Uint256{},
-			},
-		}
-	}
-
-	if n > 128 {
-		return Uint1024{
-			Lo: Uint512{
-				Lo: Uint256{
-					Lo: u.Lo.Lo.Hi.Rsh(n - 128).Or(u.Lo.Hi.Lo.Lsh(256 - n)),
-					Hi: u.Lo.Hi.Lo.Rsh(n - 128).Or(u.Lo.Hi.Hi.Lsh(256 - n)),
-				},
-				Hi: Uint256{
-					Lo: u.Lo.Hi.Hi.Rsh(n - 128).Or(u.Hi.Lo.Lo.Lsh(256 - n)),
-					Hi: u.Hi.Lo.Lo.Rsh(n - 128).Or(u.Hi.Lo.Hi.Lsh(256 - n)),
-				},
-			},
-			Hi: Uint512{
-				Lo: Uint256{
-					Lo: u.Hi.Lo.Hi.Rsh(n - 128).Or(u.Hi.Hi.Lo.Lsh(256 - n)),
-					Hi: u.Hi.Hi.Lo.Rsh(n - 128).Or(u.Hi.Hi.Hi.Lsh(256 - n)),
-				},
-				Hi: Uint256{
-					Lo: u.Hi.Hi.Hi.Rsh(n - 128),
-					Hi: Uint128{},
-				},
-			},
-		}
+	last := uint64Count - 1 - k
+	for i := 0; i < last; i++ {
+		r[i] = w[i+k]>>m | w[i+k+1]<<(64-m)
 	}
+	r[last] = w[uint64Count-1] >> m
+	return FromWords(r)
+}
 
-	if n > 64 {
-		return Uint1024{
-			Lo: Uint512{
-				Lo: Uint256{
-					Lo: Uint128{
-						Lo: u.Lo.Lo.Lo.Hi>>(n-64) | u.Lo.Lo.Hi.Lo<<(128-n),
-						Hi: u.Lo.Lo.Hi.Lo>>(n-64) | u.Lo.Lo.Hi.Hi<<(128-n),
-					},
-					Hi: Uint128{
-						Lo: u.Lo.Lo.Hi.Hi>>(n-64) | u.Lo.Hi.Lo.Lo<<(128-n),
-						Hi: u.Lo.Hi.Lo.Lo>>(n-64) | u.Lo.Hi.Lo.Hi<<(128-n),
-					},
-				},
-				Hi: Uint256{
-					Lo: Uint128{
-						Lo: u.Lo.Hi.Lo.Hi>>(n-64) | u.Lo.Hi.Hi.Lo<<(128-n),
-						Hi: u.Lo.Hi.Hi.Lo>>(n-64) | u.Lo.Hi.Hi.Hi<<(128-n),
-					},
-					Hi: Uint128{
-						Lo: u.Lo.Hi.Hi.Hi>>(n-64) | u.Hi.Lo.Lo.Lo<<(128-n),
-						Hi: u.Hi.Lo.Lo.Lo>>(n-64) | u.Hi.Lo.Lo.Hi<<(128-n),
-					},
-				},
-			},
-			Hi: Uint512{
-				Lo: Uint256{
-					Lo: Uint128{
-						Lo: u.Hi.Lo.Lo.Hi>>(n-64) | u.Hi.Lo.Hi.Lo<<(128-n),
-						Hi: u.Hi.Lo.Hi.Lo>>(n-64) | u.Hi.Lo.Hi.Hi<<(128-n),
-					},
-					Hi: Uint128{
-						Lo: u.Hi.Lo.Hi.Hi>>(n-64) | u.Hi.Hi.Lo.Lo<<(128-n),
-						Hi: u.Hi.Hi.Lo.Lo>>(n-64) | u.Hi.Hi.Lo.Hi<<(128-n),
-					},
-				},
-				Hi: Uint256{
-					Lo: Uint128{
-						Lo: u.Hi.Hi.Lo.Hi>>(n-64) | u.Hi.Hi.Hi.Lo<<(128-n),
-						Hi: u.Hi.Hi.Hi.Lo>>(n-64) | u.Hi.Hi.Hi.Hi<<(128-n),
-					},
-					Hi: Uint128{
-						Lo: u.Hi.Hi.Hi.Hi >> (n - 64),
-						Hi: 0,
-					},
-				},
-			},
-		}
+// RotateLeft returns the value of u rotated left by (k mod 1024) bits.
+func (u Uint1024) RotateLeft(k int) Uint1024 {
+	n := uint(k) & (bitCount - 1)
+	if n == 0 {
+		return u // no shift
 	}
+	return u.Lsh(n).Or(u.Rsh(bitCount - n))
+}
 
-	return Uint1024{
-		Lo: Uint512{
-			Lo: Uint256{
-				Lo: Uint128{
-					Lo: u.Lo.Lo.Lo.Lo>>n | u.Lo.Lo.Lo.Hi<<(64-n),
-					Hi: u.Lo.Lo.Lo.Hi>>n | u.Lo.Lo.Hi.Lo<<(64-n),
-				},
-				Hi: Uint128{
-					Lo: u.Lo.Lo.Hi.Lo>>n | u.Lo.Lo.Hi.Hi<<(64-n),
-					Hi: u.Lo.Lo.Hi.Hi>>n | u.Lo.Hi.Lo.Lo<<(64-n),
-				},
-			},
-			Hi: Uint256{
-				Lo: Uint128{
-					Lo: u.Lo.Hi.Lo.Lo>>n | u.Lo.Hi.Lo.Hi<<(64-n),
-					Hi: u.Lo.Hi.Lo.Hi>>n | u.Lo.Hi.Hi.Lo<<(64-n),
-				},
-				Hi: Uint128{
-					Lo: u.Lo.Hi.Hi.Lo>>n | u.Lo.Hi.Hi.Hi<<(64-n),
-					Hi: u.Lo.Hi.Hi.Hi>>n | u.Hi.Lo.Lo.Lo<<(64-n),
-				},
-			},
-		},
-		Hi: Uint512{
-			Lo: Uint256{
-				Lo: Uint128{
-					Lo: u.Hi.Lo.Lo.Lo>>n | u.Hi.Lo.Lo.Hi<<(64-n),
-					Hi: u.Hi.Lo.Lo.Hi>>n | u.Hi.Lo.Hi.Lo<<(64-n),
-				},
-				Hi: Uint128{
-					Lo: u.Hi.Lo.Hi.Lo>>n | u.Hi.Lo.Hi.Hi<<(64-n),
-					Hi: u.Hi.Lo.Hi.Hi>>n | u.Hi.Hi.Lo.Lo<<(64-n),
-				},
-			},
-			Hi: Uint256{
-				Lo: Uint128{
-					Lo: u.Hi.Hi.Lo.Lo>>n | u.Hi.Hi.Lo.Hi<<(64-n),
-					Hi: u.Hi.Hi.Lo.Hi>>n | u.Hi.Hi.Hi.Lo<<(64-n),
-				},
-				Hi: Uint128{
-					Lo: u.Hi.Hi.Hi.Lo>>n | u.Hi.Hi.Hi.Hi<<(64-n),
-					Hi: u.Hi.Hi.Hi.Hi >> n,
-				},
-			},
-		},
-	}
+// RotateRight returns the value of u rotated right by (k mod 1024) bits.
+func (u Uint1024) RotateRight(k int) Uint1024 {
+	return u.RotateLeft(-k)
 }
 
+// BitLen returns the minimum number of bits required to represent 1024-bit value.
+// The result is 0 for u == 0.
 func (u Uint1024) BitLen() int {
-	if !u.Hi.IsZero() {
-		return 512 + u.Hi.BitLen()
+	w := u.Words()
+	for i := uint64Count - 1; i >= 0; i-- {
+		if w[i] != 0 {
+			return i*64 + bits.Len64(w[i])
+		}
 	}
-	return u.Lo.BitLen()
+	return 0
 }
 
+// LeadingZeros returns the number of leading zero bits.
+// The result is 1024 for u == 0.
 func (u Uint1024) LeadingZeros() int {
-	if !u.Hi.IsZero() {
-		return u.Hi.LeadingZeros()
-	}
-	return 512 + u.Lo.LeadingZeros()
+	return bitCount - u.BitLen()
 }
 
+// TrailingZeros returns the number of trailing zero bits.
+// The result is 1024 for u == 0.
 func (u Uint1024) TrailingZeros() int {
-	if !u.Lo.IsZero() {
-		return u.Lo.TrailingZeros()
+	w := u.Words()
+	for i := 0; i < uint64Count; i++ {
+		if w[i] != 0 {
+			return i*64 + bits.TrailingZeros64(w[i])
+		}
 	}
-	return 512 + u.Hi.TrailingZeros()
+	return bitCount
 }
 
+// OnesCount returns the number of one bits ("population count").
 func (u Uint1024) OnesCount() int {
-	return u.Lo.OnesCount() + u.Hi.OnesCount()
+	var n int
+	for _, x := range u.Words() {
+		n += bits.OnesCount64(x)
+	}
+	return n
 }
 
+// Reverse returns the value with bits in reversed order.
 func (u Uint1024) Reverse() Uint1024 {
 	return Uint1024{
 		Lo: u.Hi.Reverse(),
@@ -759,6 +663,7 @@ func (u Uint1024) Reverse() Uint1024 {
 	}
 }
 
+// ReverseBytes returns the value with bytes in reversed order.
 func (u Uint1024) ReverseBytes() Uint1024 {
 	return Uint1024{
 		Lo: u.Hi.ReverseBytes(),
@@ -766,11 +671,11 @@ func (u Uint1024) ReverseBytes() Uint1024 {
 	}
 }
 
+// Bit returns true if the n-th bit of value is set, i.e. (u>>n)&1 == 1.
+// The result is false if n is out of [0, 1024) range.
 func (u Uint1024) Bit(n int) bool {
 	if n < 0 || n >= bitCount {
 		return false
 	}
-	word := n / 64
-	bit := uint(n % 64)
-	return ((*(*[uint64Count]uint64)(unsafe.Pointer(&u)))[word]>>bit)&1 == 1
+	return (u.Words()[n/64]>>uint(n%64))&1 == 1
 }
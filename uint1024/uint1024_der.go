@@ -0,0 +1,76 @@
+package uint1024
+
+import (
+	"encoding/asn1"
+	"fmt"
+
+	"github.com/piliming/bigz/internal/nat"
+)
+
+// ASN.1 DER encoding of INTEGER: tag 0x02, minimal length and minimal
+// two's complement content, i.e. with leading 0x00 if the high bit is set.
+//
+// For encoding/asn1 use asn1.RawValue fields, see RawValue and FromRawValue.
+
+// AppendDER appends DER encoded INTEGER u to dst.
+func (u Uint1024) AppendDER(dst []byte) []byte {
+	w := u.Words()
+	return nat.AppendDER(dst, w[:])
+}
+
+// MarshalDER returns DER encoded INTEGER u.
+func (u Uint1024) MarshalDER() ([]byte, error) {
+	return u.AppendDER(nil), nil
+}
+
+// UnmarshalDER decodes DER encoded INTEGER which must be the whole data.
+// See DecodeDER for accepted encodings.
+func (u *Uint1024) UnmarshalDER(data []byte) error {
+	x, rest, err := DecodeDER(data)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 0 {
+		return fmt.Errorf("der: %d bytes of trailing data", len(rest))
+	}
+	*u = x
+	return nil
+}
+
+// DecodeDER decodes DER encoded INTEGER from the front of b and returns
+// it with the rest of b. Non-minimal length or content, negative values
+// and values overflowing 1024-bit are rejected.
+func DecodeDER(b []byte) (Uint1024, []byte, error) {
+	var w [uint64Count]uint64
+	rest, err := nat.DecodeDER(w[:], b)
+	if err != nil {
+		return Zero(), rest, err
+	}
+	return FromWords(w), rest, nil
+}
+
+// RawValue returns u as asn1.RawValue of universal INTEGER,
+// ready to be used with asn1.Marshal.
+func (u Uint1024) RawValue() asn1.RawValue {
+	full := u.AppendDER(nil)
+	return asn1.RawValue{
+		Class:     asn1.ClassUniversal,
+		Tag:       asn1.TagInteger,
+		Bytes:     full[len(full)-u.BitLen()/8-1:], // content incl. leading zero
+		FullBytes: full,
+	}
+}
+
+// FromRawValue converts asn1.RawValue of universal INTEGER,
+// e.g. decoded by asn1.Unmarshal, to Uint1024. The content must be
+// minimal, non-negative and fit 1024-bit.
+func FromRawValue(v asn1.RawValue) (Uint1024, error) {
+	if v.Class != asn1.ClassUniversal || v.Tag != asn1.TagInteger || v.IsCompound {
+		return Zero(), fmt.Errorf("der: expected INTEGER, got class %d tag %d", v.Class, v.Tag)
+	}
+	var w [uint64Count]uint64
+	if err := nat.DecodeDERContent(w[:], v.Bytes); err != nil {
+		return Zero(), err
+	}
+	return FromWords(w), nil
+}
@@ -2,41 +2,62 @@ package uint1024
 
 import (
 	"fmt"
+	"github.com/piliming/bigz/internal/nat"
 	"github.com/piliming/bigz/uint512"
 	"math/big"
 )
 
-// FromString parses input string as a Uint256 value.
+// FromString parses input string as a Uint1024 value.
 func FromString(s string) (Uint1024, error) {
 	var u Uint1024
 	_, err := fmt.Sscan(s, &u)
 	return u, err
 }
 
-func (u Uint1024) String() string {
-	if u.Hi.IsZero() {
-		return u.Lo.String()
-	}
+// ParseUint interprets a string s in the given base (0, 2 to 36) and
+// returns the corresponding 1024-bit value. It works like strconv.ParseUint:
+// if base is 0, it is implied by the prefix (0b, 0o or 0, 0x) and
+// underscores are permitted as digit separators.
+//
+// The error is *strconv.NumError. On overflow, the result is Max()
+// and the error is strconv.ErrRange. There is no memory allocation
+// unless an error is returned.
+func ParseUint(s string, base int) (Uint1024, error) {
+	var w [16]uint64
+	err := nat.ParseUint(w[:], s, base)
+	return FromWords(w), err
+}
 
-	buf := []byte("000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000")
-	for i := len(buf); ; i -= 19 {
-		q, r := u.QuoRem64(1e19)
-		var n int
-		for ; r != 0; r /= 10 {
-			n++
-			buf[i-n] += byte(r % 10)
-		}
-
-		if q.IsZero() {
-			return string(buf[i-n:])
-		}
-		u = q
-	}
+// FormatUint returns the string representation of u in the given base,
+// for 2 <= base <= 36. The result uses the lower-case letters 'a' to 'z'
+// for digit values >= 10. It panics if base is out of range.
+func FormatUint(u Uint1024, base int) string {
+	w := u.Words()
+	return string(nat.AppendDigits(nil, w[:], base))
+}
+
+// AppendUint appends the string form of u, as generated by FormatUint,
+// to dst and returns the extended buffer.
+func AppendUint(dst []byte, u Uint1024, base int) []byte {
+	w := u.Words()
+	return nat.AppendDigits(dst, w[:], base)
+}
+
+// String returns the base-10 representation of 1024-bit value.
+func (u Uint1024) String() string {
+	var buf [309]byte // log10(2^1024) < 309
+	w := u.Words()
+	return string(nat.AppendDigits(buf[:0], w[:], 10))
 }
 
 // Format does custom formatting of 1024-bit value.
+//
+// The output is the same as of (*big.Int).Format for all supported verbs:
+// 'b', 'o', 'O', 'd', 's', 'v', 'x' and 'X', including width, precision
+// and '#', '+', '-', '0', ' ' flags.
 func (u Uint1024) Format(s fmt.State, ch rune) {
-	u.Big().Format(s, ch) // via big.Int, unefficient! consider to optimize
+	w := u.Words()
+	nat.Format(s, ch, w[:])
 }
 
 // Scan implements fmt.Scanner.
@@ -56,53 +77,86 @@ func (u *Uint1024) Scan(s fmt.ScanState, ch rune) error {
 }
 
 // MarshalText implements the encoding.TextMarshaler interface.
+// The text is the base-10 representation, the same as String().
 func (u Uint1024) MarshalText() (text []byte, err error) {
-	return u.Big().MarshalText() // via big.Int, unefficient! consider to optimize
+	return u.AppendText(nil)
+}
+
+// AppendText implements the encoding.TextAppender interface.
+// It appends the same text as MarshalText to dst.
+func (u Uint1024) AppendText(dst []byte) ([]byte, error) {
+	return u.AppendDecimal(dst), nil
+}
+
+// AppendDecimal appends the base-10 representation of u to dst.
+func (u Uint1024) AppendDecimal(dst []byte) []byte {
+	w := u.Words()
+	return nat.AppendDigits(dst, w[:], 10)
+}
+
+// AppendHex appends the lower case base-16 representation of u to dst,
+// without "0x" prefix. If padded is true, exactly 256 digits are appended.
+func (u Uint1024) AppendHex(dst []byte, padded bool) []byte {
+	w := u.Words()
+	if padded {
+		return nat.AppendHexPadded(dst, w[:])
+	}
+	return nat.AppendDigits(dst, w[:], 16)
+}
+
+// AppendBinary implements the encoding.BinaryAppender interface.
+// It appends 128 bytes of u to dst in big-endian byte order.
+func (u Uint1024) AppendBinary(dst []byte) ([]byte, error) {
+	w := u.Words()
+	return nat.AppendBigEndian(dst, w[:]), nil
 }
 
 // UnmarshalText implements the encoding.TextUnmarshaler interface.
+// It accepts the same text as ParseUint with base 0, e.g. "123" or "0x7b".
+// Unlike FromString and Scan, which go through big.Int, a sign is not
+// accepted: "+1" is a syntax error. The error is *strconv.NumError. There is no memory allocation unless
+// an error is returned.
 func (u *Uint1024) UnmarshalText(text []byte) error {
-	// via big.Int, unefficient! consider to optimize
-	i := new(big.Int)
-	if err := i.UnmarshalText(text); err != nil {
+	var w [16]uint64
+	if err := nat.ParseUint(w[:], text, 0); err != nil {
 		return err
 	}
-	v, ok := FromBigEx(i)
-	if !ok {
-		return fmt.Errorf("%q overflows 1024-bit integer", text)
-	}
-	*u = v
+	*u = FromWords(w)
 	return nil
 }
 
-//// StoreLittleEndian stores 256-bit value in byte slice in little-endian byte order.
-//// It panics if byte slice length is less than 32.
-//func StoreLittleEndian(b []byte, u Uint256) {
-//	uint128.StoreLittleEndian(b[:16], u.Lo)
-//	uint128.StoreLittleEndian(b[16:], u.Hi)
-//}
-//
-//// StoreBigEndian stores 256-bit value in byte slice in big-endian byte order.
-//// It panics if byte slice length is less than 32.
-//func StoreBigEndian(b []byte, u Uint256) {
-//	uint128.StoreBigEndian(b[:16], u.Hi)
-//	uint128.StoreBigEndian(b[16:], u.Lo)
-//}
+// StoreLittleEndian stores 1024-bit value in byte slice in little-endian byte order.
+// It panics if byte slice length is less than 128.
+func StoreLittleEndian(b []byte, u Uint1024) {
+	_ = b[byteCount-1] // early bounds check
+	uint512.StoreLittleEndian(b[:byteCount/2], u.Lo)
+	uint512.StoreLittleEndian(b[byteCount/2:], u.Hi)
+}
+
+// StoreBigEndian stores 1024-bit value in byte slice in big-endian byte order.
+// It panics if byte slice length is less than 128.
+func StoreBigEndian(b []byte, u Uint1024) {
+	_ = b[byteCount-1] // early bounds check
+	uint512.StoreBigEndian(b[:byteCount/2], u.Hi)
+	uint512.StoreBigEndian(b[byteCount/2:], u.Lo)
+}
 
 // LoadLittleEndian loads 1024-bit value from byte slice in little-endian byte order.
-// It panics if byte slice length is less than 32.
-func LoadLittleEndian(b [128]byte) Uint1024 {
+// It panics if byte slice length is less than 128.
+func LoadLittleEndian(b []byte) Uint1024 {
+	_ = b[byteCount-1] // early bounds check
 	return Uint1024{
-		Lo: uint512.LoadLittleEndian([64]byte(b[:64])),
-		Hi: uint512.LoadLittleEndian([64]byte(b[64:])),
+		Lo: uint512.LoadLittleEndian(b[:byteCount/2]),
+		Hi: uint512.LoadLittleEndian(b[byteCount/2:]),
 	}
 }
 
-//// LoadBigEndian loads 256-bit value from byte slice in big-endian byte order.
-//// It panics if byte slice length is less than 32.
-//func LoadBigEndian(b []byte) Uint256 {
-//	return Uint256{
-//		Lo: uint128.LoadBigEndian(b[16:]),
-//		Hi: uint128.LoadBigEndian(b[:16]),
-//	}
-//}
+// LoadBigEndian loads 1024-bit value from byte slice in big-endian byte order.
+// It panics if byte slice length is less than 128.
+func LoadBigEndian(b []byte) Uint1024 {
+	_ = b[byteCount-1] // early bounds check
+	return Uint1024{
+		Lo: uint512.LoadBigEndian(b[byteCount/2:]),
+		Hi: uint512.LoadBigEndian(b[:byteCount/2]),
+	}
+}
@@ -0,0 +1,48 @@
+package uint1024
+
+import "github.com/piliming/bigz/internal/nat"
+
+// FormatGrouped returns u in decimal with sep between every group digits
+// counting from the right, e.g. FormatGrouped(u, ",", 3) is "1,234,567".
+// Digits are not grouped if group < 1.
+func FormatGrouped(u Uint1024, sep string, group int) string {
+	w := u.Words()
+	return string(nat.AppendGrouped(nil, w[:], sep, group))
+}
+
+// FormatSI returns u scaled by the largest SI prefix, from k (10^3)
+// to Q (10^30), with prec fraction digits rounded half to even,
+// e.g. "1.23M". If prec < 0 the exact value is used, e.g. "1.234567M".
+func FormatSI(u Uint1024, prec int) string {
+	w := u.Words()
+	return string(nat.AppendSI(nil, w[:], prec))
+}
+
+// FormatIEC returns u scaled by the largest IEC binary prefix, from Ki (2^10)
+// to Yi (2^80), with prec fraction digits rounded half to even,
+// e.g. "1.50Ki". If prec < 0 the exact value is used.
+func FormatIEC(u Uint1024, prec int) string {
+	w := u.Words()
+	return string(nat.AppendIEC(nil, w[:], prec))
+}
+
+// FormatSci returns u in scientific notation with prec fraction digits
+// rounded half to even, e.g. "1.2345e+76". If prec < 0 the exact value
+// is used, like strconv.FormatFloat with 'e' format.
+func FormatSci(u Uint1024, prec int) string {
+	w := u.Words()
+	return string(nat.AppendSci(nil, w[:], prec))
+}
+
+// ParseHuman interprets human-readable s, the inverse of the Format
+// functions above: decimal with optional fraction and exponent, followed
+// by optional SI or IEC prefix, e.g. "1234", "1.5M", "3e18" or "1.5Ki".
+// The value must be an integer, "1.2345k" is an error. Errors are
+// *strconv.NumError, values overflowing 1024-bit are strconv.ErrRange.
+func ParseHuman(s string) (Uint1024, error) {
+	var w [16]uint64
+	if err := nat.ParseHuman(w[:], s); err != nil {
+		return FromWords(w), err
+	}
+	return FromWords(w), nil
+}
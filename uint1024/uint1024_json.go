@@ -0,0 +1,75 @@
+package uint1024
+
+import (
+	"fmt"
+
+	"github.com/piliming/bigz/internal/nat"
+)
+
+// MarshalJSON implements the json.Marshaler interface.
+// The value is encoded as a quoted decimal string, e.g. "123",
+// which is safe for JavaScript clients. See JSONNumber and JSONHex
+// for other encodings.
+func (u Uint1024) MarshalJSON() ([]byte, error) {
+	buf := make([]byte, 0, 311)
+	buf = append(buf, '"')
+	buf = u.AppendDecimal(buf)
+	return append(buf, '"'), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It accepts a bare JSON number, e.g. 123, a quoted decimal string,
+// e.g. "123", or a quoted hex string with "0x" prefix, e.g. "0x7b".
+// The JSON null value is a no-op.
+func (u *Uint1024) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var w [16]uint64
+	if err := nat.ParseJSON(w[:], data); err != nil {
+		return fmt.Errorf("%s is not a valid 1024-bit integer: %w", data, err)
+	}
+	*u = FromWords(w)
+	return nil
+}
+
+// JSONNumber is a Uint1024 encoded as a bare JSON number, e.g. 123,
+// for APIs that accept big numerics. Decoding is the same as of Uint1024.
+type JSONNumber Uint1024
+
+// String returns the base-10 representation of the value.
+func (u JSONNumber) String() string {
+	return Uint1024(u).String()
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (u JSONNumber) MarshalJSON() ([]byte, error) {
+	return Uint1024(u).AppendDecimal(make([]byte, 0, 309)), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (u *JSONNumber) UnmarshalJSON(data []byte) error {
+	return (*Uint1024)(u).UnmarshalJSON(data)
+}
+
+// JSONHex is a Uint1024 encoded as a quoted hex string with "0x" prefix
+// and without leading zeros, e.g. "0x7b". Decoding is the same as of Uint1024.
+type JSONHex Uint1024
+
+// String returns the base-10 representation of the value.
+func (u JSONHex) String() string {
+	return Uint1024(u).String()
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (u JSONHex) MarshalJSON() ([]byte, error) {
+	buf := make([]byte, 0, 260)
+	buf = append(buf, '"', '0', 'x')
+	buf = Uint1024(u).AppendHex(buf, false)
+	return append(buf, '"'), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (u *JSONHex) UnmarshalJSON(data []byte) error {
+	return (*Uint1024)(u).UnmarshalJSON(data)
+}
@@ -0,0 +1,50 @@
+package uint1024
+
+import (
+	"fmt"
+
+	"github.com/piliming/bigz/internal/nat"
+)
+
+// Ordered keys are for sorted key-value stores like Pebble or Badger:
+// the bytewise order of keys matches Cmp, so range scans iterate values
+// in numeric order. The key is the count of significant bytes followed
+// by these bytes in big-endian order, e.g. 0x1234 is 02 12 34, so small
+// values are short. Keys are prefix-free and can be followed by other
+// key parts. Fixed-size keys of 128 bytes are MarshalBinary output.
+
+// AppendOrderedKey appends the ascending order key of u to dst.
+func (u Uint1024) AppendOrderedKey(dst []byte) []byte {
+	w := u.Words()
+	return nat.AppendOrderedKey(dst, w[:], false)
+}
+
+// AppendOrderedKeyDesc appends the descending order key of u to dst,
+// the ascending key with all bytes complemented.
+func (u Uint1024) AppendOrderedKeyDesc(dst []byte) []byte {
+	w := u.Words()
+	return nat.AppendOrderedKey(dst, w[:], true)
+}
+
+// DecodeOrderedKey decodes the ascending order key from the front of b
+// and returns the value with the rest of b. Non-canonical keys and values
+// overflowing 1024-bit are rejected.
+func DecodeOrderedKey(b []byte) (Uint1024, []byte, error) {
+	return decodeOrderedKey(b, false)
+}
+
+// DecodeOrderedKeyDesc decodes the descending order key from the front
+// of b and returns the value with the rest of b, see DecodeOrderedKey.
+func DecodeOrderedKeyDesc(b []byte) (Uint1024, []byte, error) {
+	return decodeOrderedKey(b, true)
+}
+
+// decodeOrderedKey decodes ascending or descending order key.
+func decodeOrderedKey(b []byte, desc bool) (Uint1024, []byte, error) {
+	var w [16]uint64
+	rest, err := nat.DecodeOrderedKey(w[:], b, desc)
+	if err != nil {
+		return Zero(), rest, fmt.Errorf("invalid 1024-bit key: %w", err)
+	}
+	return FromWords(w), rest, nil
+}
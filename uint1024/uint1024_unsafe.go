@@ -0,0 +1,16 @@
+//go:build !purego && (386 || amd64 || arm || arm64 || loong64 || mips64le || mipsle || ppc64le || riscv64 || wasm)
+
+package uint1024
+
+import "unsafe"
+
+// Note, Uint1024 is a tree of Lo/Hi halves down to uint64 limbs, so in memory
+// it is exactly 16 limbs going from the least significant to the most significant.
+// On little-endian hosts these bytes are the little-endian representation
+// of the value and can be reinterpreted without any conversion.
+// TestLayout checks this assumption, build with "purego" tag to avoid unsafe.
+
+// littleEndianBytes returns 1024-bit value in little-endian byte order.
+func (u Uint1024) littleEndianBytes() [byteCount]byte {
+	return *(*[byteCount]byte)(unsafe.Pointer(&u))
+}
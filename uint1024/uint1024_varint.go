@@ -0,0 +1,53 @@
+package uint1024
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/piliming/bigz/internal/nat"
+)
+
+// Unsigned LEB128 (protobuf varint) encoding: 7 bits per byte starting
+// from the least significant ones, the high bit of every byte but the
+// last one is set. Small values take few bytes, e.g. zero is one byte.
+
+// MaxVarintLen is the maximum length of LEB128 encoded Uint1024.
+const MaxVarintLen = 147
+
+// AppendUvarint appends the LEB128 encoding of u to dst.
+func (u Uint1024) AppendUvarint(dst []byte) []byte {
+	w := u.Words()
+	return nat.AppendUvarint(dst, w[:])
+}
+
+// DecodeUvarint decodes the LEB128 encoded value from the front of b
+// and returns it with the rest of b. Truncated input (io.ErrUnexpectedEOF,
+// or io.EOF if b is empty), values overflowing 1024-bit and non-canonical
+// encodings with trailing zero bytes are rejected.
+func DecodeUvarint(b []byte) (Uint1024, []byte, error) {
+	var w [uint64Count]uint64
+	n, err := nat.Uvarint(w[:], b)
+	if err != nil {
+		return Zero(), b, varintError(err)
+	}
+	return FromWords(w), b[n:], nil
+}
+
+// ReadUvarint reads the LEB128 encoded value from r.
+// The errors are the same as of DecodeUvarint, io.EOF is returned
+// only if no bytes were read.
+func ReadUvarint(r io.ByteReader) (Uint1024, error) {
+	var w [uint64Count]uint64
+	if err := nat.ReadUvarint(w[:], r); err != nil {
+		return Zero(), varintError(err)
+	}
+	return FromWords(w), nil
+}
+
+// varintError adds the type name to decoding errors, io errors are returned as is.
+func varintError(err error) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return err
+	}
+	return fmt.Errorf("invalid 1024-bit varint: %w", err)
+}
@@ -0,0 +1,51 @@
+package bigz
+
+import (
+	"math/big"
+
+	u1024 "github.com/piliming/bigz/uint1024"
+)
+
+// Uint1024 is type alias for 1024-bit unsigned integer.
+type Uint1024 = u1024.Uint1024
+
+// Note, there in no New(lo, hi) just not to confuse
+// which half goes first: lower or upper.
+// Use structure initialization Uint1024{Lo: ..., Hi: ...} instead.
+
+// Zero1024 is the lowest possible Uint1024 value.
+func Zero1024() Uint1024 {
+	return u1024.Zero()
+}
+
+// One1024 is the lowest non-zero Uint1024 value.
+func One1024() Uint1024 {
+	return u1024.One()
+}
+
+// Max1024 is the largest possible Uint1024 value.
+func Max1024() Uint1024 {
+	return u1024.Max()
+}
+
+// From64To1024 converts 64-bit value v to a Uint1024 value.
+func From64To1024(v uint64) Uint1024 {
+	return u1024.From64(v)
+}
+
+// FromBig1024 converts *big.Int to Uint1024 value with saturation.
+// See FromBigEx1024 to detect overflows.
+func FromBig1024(i *big.Int) Uint1024 {
+	return u1024.FromBig(i)
+}
+
+// FromBigEx1024 converts *big.Int to Uint1024 value (eXtended version).
+// Provides ok successful flag as a second return value.
+func FromBigEx1024(i *big.Int) (Uint1024, bool) {
+	return u1024.FromBigEx(i)
+}
+
+// Parse1024 parses input string as a Uint1024 value.
+func Parse1024(s string) (Uint1024, error) {
+	return u1024.FromString(s)
+}
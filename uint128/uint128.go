@@ -88,6 +88,18 @@ func (u Uint128) Big() *big.Int {
 	return i
 }
 
+// Words returns 128-bit value as an array of 64-bit limbs
+// in little-endian order, i.e. Words()[0] is the least significant limb.
+func (u Uint128) Words() [2]uint64 {
+	return [2]uint64{u.Lo, u.Hi}
+}
+
+// FromWords converts an array of 64-bit limbs in little-endian order
+// (the least significant limb goes first) to a Uint128 value.
+func FromWords(w [2]uint64) Uint128 {
+	return Uint128{Lo: w[0], Hi: w[1]}
+}
+
 // IsZero returns true if stored 128-bit value is zero.
 func (u Uint128) IsZero() bool {
 	return (u.Lo == 0) && (u.Hi == 0)
@@ -531,3 +543,12 @@ func (u Uint128) ReverseBytes() Uint128 {
 		Hi: bits.ReverseBytes64(u.Lo),
 	}
 }
+
+// Bit returns true if the n-th bit of value is set, i.e. (u>>n)&1 == 1.
+// The result is false if n is out of [0, 128) range.
+func (u Uint128) Bit(n int) bool {
+	if n < 0 || n >= 128 {
+		return false
+	}
+	return (u.Words()[n/64]>>uint(n%64))&1 == 1
+}
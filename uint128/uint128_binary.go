@@ -0,0 +1,158 @@
+package uint128
+
+import (
+	"fmt"
+	"io"
+)
+
+// The binary layout of Uint128 is fixed: 16 bytes in big-endian byte order,
+// i.e. the most significant byte goes first. The compact layout is the same
+// but without leading zero bytes, just like (*big.Int).Bytes.
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// The result is always 16 bytes in big-endian byte order.
+func (u Uint128) MarshalBinary() ([]byte, error) {
+	return u.AppendBinary(make([]byte, 0, 16))
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+// The data must be exactly 16 bytes in big-endian byte order.
+func (u *Uint128) UnmarshalBinary(data []byte) error {
+	if len(data) != 16 {
+		return fmt.Errorf("invalid 128-bit binary length %d, expected 16", len(data))
+	}
+	*u = LoadBigEndian(data)
+	return nil
+}
+
+// GobEncode implements the gob.GobEncoder interface.
+// The layout is the same as of MarshalBinary.
+func (u Uint128) GobEncode() ([]byte, error) {
+	return u.MarshalBinary()
+}
+
+// GobDecode implements the gob.GobDecoder interface.
+// The layout is the same as of UnmarshalBinary.
+func (u *Uint128) GobDecode(data []byte) error {
+	return u.UnmarshalBinary(data)
+}
+
+// Bytes returns the compact big-endian representation of u,
+// i.e. without leading zero bytes. The result is empty for zero.
+func (u Uint128) Bytes() []byte {
+	var buf [16]byte
+	StoreBigEndian(buf[:], u)
+	i := 0
+	for i < len(buf) && buf[i] == 0 {
+		i++
+	}
+	return append([]byte{}, buf[i:]...)
+}
+
+// FromBytes converts the big-endian byte slice of any length to Uint128.
+// Leading zero bytes are ignored, so it is the inverse of Bytes and
+// of MarshalBinary. If the value overflows 128-bit then ok=false
+// and Max() is returned.
+func FromBytes(b []byte) (Uint128, bool) {
+	for len(b) > 0 && b[0] == 0 {
+		b = b[1:]
+	}
+	if len(b) > 16 {
+		return Max(), false // value overflows 128-bit!
+	}
+	var buf [16]byte
+	copy(buf[16-len(b):], b)
+	return LoadBigEndian(buf[:]), true
+}
+
+// FillBytes sets buf to u, storing it as a zero-extended big-endian byte slice,
+// and returns buf. Just like (*big.Int).FillBytes, buf may be shorter than 16
+// bytes if the value fits, otherwise it panics.
+func (u Uint128) FillBytes(buf []byte) []byte {
+	var tmp [16]byte
+	StoreBigEndian(tmp[:], u)
+	if n := len(tmp) - len(buf); n > 0 {
+		for _, c := range tmp[:n] {
+			if c != 0 {
+				panic("uint128: buffer too small to fit value")
+			}
+		}
+		copy(buf, tmp[n:])
+	} else {
+		for i := range buf[:-n] {
+			buf[i] = 0
+		}
+		copy(buf[-n:], tmp[:])
+	}
+	return buf
+}
+
+// SetBytes sets u to the value of big-endian byte slice of any length
+// and reports whether there is no overflow. It is the same as FromBytes.
+func (u *Uint128) SetBytes(b []byte) (ok bool) {
+	*u, ok = FromBytes(b)
+	return ok
+}
+
+// StoreLittleEndianArray stores 128-bit value in byte array in little-endian byte order.
+func StoreLittleEndianArray(b *[16]byte, u Uint128) {
+	StoreLittleEndian(b[:], u)
+}
+
+// StoreBigEndianArray stores 128-bit value in byte array in big-endian byte order.
+func StoreBigEndianArray(b *[16]byte, u Uint128) {
+	StoreBigEndian(b[:], u)
+}
+
+// LoadLittleEndianArray loads 128-bit value from byte array in little-endian byte order.
+func LoadLittleEndianArray(b *[16]byte) Uint128 {
+	return LoadLittleEndian(b[:])
+}
+
+// LoadBigEndianArray loads 128-bit value from byte array in big-endian byte order.
+func LoadBigEndianArray(b *[16]byte) Uint128 {
+	return LoadBigEndian(b[:])
+}
+
+// StoreLittleEndianChecked stores 128-bit value in byte slice in little-endian byte order.
+// Unlike StoreLittleEndian it returns an error if byte slice length is less than 16.
+func StoreLittleEndianChecked(b []byte, u Uint128) error {
+	if len(b) < 16 {
+		return shortBufferError(len(b))
+	}
+	StoreLittleEndian(b, u)
+	return nil
+}
+
+// StoreBigEndianChecked stores 128-bit value in byte slice in big-endian byte order.
+// Unlike StoreBigEndian it returns an error if byte slice length is less than 16.
+func StoreBigEndianChecked(b []byte, u Uint128) error {
+	if len(b) < 16 {
+		return shortBufferError(len(b))
+	}
+	StoreBigEndian(b, u)
+	return nil
+}
+
+// LoadLittleEndianChecked loads 128-bit value from byte slice in little-endian byte order.
+// Unlike LoadLittleEndian it returns an error if byte slice length is less than 16.
+func LoadLittleEndianChecked(b []byte) (Uint128, error) {
+	if len(b) < 16 {
+		return Zero(), shortBufferError(len(b))
+	}
+	return LoadLittleEndian(b), nil
+}
+
+// LoadBigEndianChecked loads 128-bit value from byte slice in big-endian byte order.
+// Unlike LoadBigEndian it returns an error if byte slice length is less than 16.
+func LoadBigEndianChecked(b []byte) (Uint128, error) {
+	if len(b) < 16 {
+		return Zero(), shortBufferError(len(b))
+	}
+	return LoadBigEndian(b), nil
+}
+
+// shortBufferError returns io.ErrShortBuffer based error.
+func shortBufferError(n int) error {
+	return fmt.Errorf("%d bytes is not enough for 128-bit integer: %w", n, io.ErrShortBuffer)
+}
@@ -0,0 +1,157 @@
+package uint128
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"io"
+	"testing"
+)
+
+// TestBinary unit tests for binary marshaling functions
+func TestBinary(t *testing.T) {
+	t.Run("manual", func(t *testing.T) {
+		if got := Zero().Bytes(); len(got) != 0 {
+			t.Errorf("Zero().Bytes() should be empty, got %x", got)
+		}
+		if got := From64(0x0102).Bytes(); !bytes.Equal(got, []byte{1, 2}) {
+			t.Errorf("Bytes() should be 0102, got %x", got)
+		}
+		if got, ok := FromBytes(make([]byte, 100)); !ok || !got.IsZero() {
+			t.Errorf("FromBytes(zeros) should be zero, got %v, %v", got, ok)
+		}
+		if got, ok := FromBytes(append([]byte{1}, make([]byte, 16)...)); ok || !got.Equals(Max()) {
+			t.Errorf("FromBytes(overflow) should be Max(), got %v, %v", got, ok)
+		}
+
+		var u Uint128
+		for _, n := range []int{0, 1, 16 - 1, 16 + 1} {
+			if err := u.UnmarshalBinary(make([]byte, n)); err == nil {
+				t.Errorf("UnmarshalBinary(%d bytes) should fail", n)
+			}
+		}
+
+		short := make([]byte, 16-1)
+		if _, err := LoadLittleEndianChecked(short); !errors.Is(err, io.ErrShortBuffer) {
+			t.Errorf("LoadLittleEndianChecked should fail on short buffer, got %v", err)
+		}
+		if _, err := LoadBigEndianChecked(short); !errors.Is(err, io.ErrShortBuffer) {
+			t.Errorf("LoadBigEndianChecked should fail on short buffer, got %v", err)
+		}
+		if err := StoreLittleEndianChecked(short, One()); !errors.Is(err, io.ErrShortBuffer) {
+			t.Errorf("StoreLittleEndianChecked should fail on short buffer, got %v", err)
+		}
+		if err := StoreBigEndianChecked(short, One()); !errors.Is(err, io.ErrShortBuffer) {
+			t.Errorf("StoreBigEndianChecked should fail on short buffer, got %v", err)
+		}
+	})
+
+	t.Run("rand", func(t *testing.T) {
+		values := make(chan Uint128)
+		go generate128s(1000, values)
+		for x := range values {
+			// fixed size
+			buf, err := x.MarshalBinary()
+			if err != nil || !bytes.Equal(buf, x.Big().FillBytes(make([]byte, 16))) {
+				t.Fatalf("MarshalBinary(%#x) mismatch: %x, %v", x, buf, err)
+			}
+			var u Uint128
+			if err := u.UnmarshalBinary(buf); err != nil || !u.Equals(x) {
+				t.Fatalf("UnmarshalBinary is not the inverse of MarshalBinary for %#x, got %#x, %v", x, u, err)
+			}
+			if u, ok := FromBytes(buf); !ok || !u.Equals(x) {
+				t.Fatalf("FromBytes is not the inverse of MarshalBinary for %#x, got %#x", x, u)
+			}
+
+			// compact
+			compact := x.Bytes()
+			if !bytes.Equal(compact, x.Big().Bytes()) {
+				t.Fatalf("Bytes(%#x) mismatch: %x", x, compact)
+			}
+			if u, ok := FromBytes(compact); !ok || !u.Equals(x) {
+				t.Fatalf("FromBytes is not the inverse of Bytes for %#x, got %#x", x, u)
+			}
+
+			// checked
+			if err := StoreLittleEndianChecked(buf, x); err != nil {
+				t.Fatalf("StoreLittleEndianChecked failed: %v", err)
+			}
+			if u, err := LoadLittleEndianChecked(buf); err != nil || !u.Equals(x) {
+				t.Fatalf("LoadLittleEndianChecked is not the inverse of StoreLittleEndianChecked for %#x, got %#x, %v", x, u, err)
+			}
+			if err := StoreBigEndianChecked(buf, x); err != nil {
+				t.Fatalf("StoreBigEndianChecked failed: %v", err)
+			}
+			if u, err := LoadBigEndianChecked(buf); err != nil || !u.Equals(x) {
+				t.Fatalf("LoadBigEndianChecked is not the inverse of StoreBigEndianChecked for %#x, got %#x, %v", x, u, err)
+			}
+		}
+	})
+
+	t.Run("bytes", func(t *testing.T) {
+		values := make(chan Uint128)
+		go generate128s(1000, values)
+		for x := range values {
+			// FillBytes is the same as for big.Int
+			for _, size := range []int{len(x.Bytes()), 16, 16 + 5} {
+				expected := x.Big().FillBytes(make([]byte, size))
+				if got := x.FillBytes(bytes.Repeat([]byte{0xff}, size)); !bytes.Equal(got, expected) {
+					t.Fatalf("FillBytes(%d) mismatch for %#x:\n\t(-) expected %x\n\t(+)   actual %x", size, x, expected, got)
+				}
+			}
+
+			var u Uint128
+			if ok := u.SetBytes(x.Bytes()); !ok || !u.Equals(x) {
+				t.Fatalf("SetBytes is not the inverse of Bytes for %#x, got %#x", x, u)
+			}
+
+			var buf [16]byte
+			StoreLittleEndianArray(&buf, x)
+			if got := LoadLittleEndianArray(&buf); !got.Equals(x) {
+				t.Fatalf("LoadLittleEndianArray is not the inverse of StoreLittleEndianArray for %#x, got %#x", x, got)
+			}
+			StoreBigEndianArray(&buf, x)
+			if got := LoadBigEndianArray(&buf); !got.Equals(x) || !bytes.Equal(buf[:], x.FillBytes(make([]byte, 16))) {
+				t.Fatalf("LoadBigEndianArray is not the inverse of StoreBigEndianArray for %#x, got %#x", x, got)
+			}
+		}
+
+		var u Uint128
+		if ok := u.SetBytes(make([]byte, 16+1)); !ok || !u.IsZero() {
+			t.Errorf("SetBytes(zeros) should be zero, got %v", u)
+		}
+		if ok := u.SetBytes(bytes.Repeat([]byte{1}, 16+1)); ok || !u.Equals(Max()) {
+			t.Errorf("SetBytes(overflow) should be Max(), got %v", u)
+		}
+
+		defer func() {
+			if recover() == nil {
+				t.Errorf("FillBytes should panic on too small buffer")
+			}
+		}()
+		Max().FillBytes(make([]byte, 16-1))
+	})
+
+	t.Run("gob", func(t *testing.T) {
+		type Foo struct {
+			Bar Uint128
+			Baz []Uint128
+		}
+
+		values := make(chan Uint128)
+		go generate128s(100, values)
+		for x := range values {
+			var buf bytes.Buffer
+			if err := gob.NewEncoder(&buf).Encode(Foo{Bar: x, Baz: []Uint128{x, Max()}}); err != nil {
+				t.Fatalf("failed to gob encode: %v", err)
+			}
+			var tmp Foo
+			if err := gob.NewDecoder(&buf).Decode(&tmp); err != nil {
+				t.Fatalf("failed to gob decode: %v", err)
+			}
+			if !tmp.Bar.Equals(x) || len(tmp.Baz) != 2 || !tmp.Baz[0].Equals(x) || !tmp.Baz[1].Equals(Max()) {
+				t.Fatalf("%#x does not equal itself after gob decoding, got: %+v", x, tmp)
+			}
+		}
+	})
+}
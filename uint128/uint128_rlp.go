@@ -0,0 +1,41 @@
+package uint128
+
+import (
+	"io"
+
+	"github.com/piliming/bigz/internal/nat"
+)
+
+// Canonical RLP (Ethereum Recursive Length Prefix) encoding of integers:
+// the string of minimal big-endian bytes, zero is the empty string (0x80)
+// and values below 0x80 are encoded as a single byte.
+//
+// EncodeRLP implements go-ethereum's rlp.Encoder interface. This package
+// does not depend on go-ethereum, so there is no rlp.Decoder method:
+// decode the raw item with the DecodeRLP function instead, e.g.
+// `raw, _ := s.Raw(); u, _, err := uint128.DecodeRLP(raw)`.
+
+// AppendRLP appends the canonical RLP encoding of u to dst.
+func (u Uint128) AppendRLP(dst []byte) []byte {
+	w := u.Words()
+	return nat.AppendRLP(dst, w[:])
+}
+
+// EncodeRLP writes the canonical RLP encoding of u to w.
+func (u Uint128) EncodeRLP(w io.Writer) error {
+	var buf [1 + 16]byte
+	_, err := w.Write(u.AppendRLP(buf[:0]))
+	return err
+}
+
+// DecodeRLP decodes the canonical RLP encoded integer from the front of b
+// and returns it with the rest of b. It rejects lists, leading zero bytes,
+// non-minimal size prefixes, truncated input and values overflowing 128-bit.
+func DecodeRLP(b []byte) (Uint128, []byte, error) {
+	var w [2]uint64
+	rest, err := nat.DecodeRLP(w[:], b)
+	if err != nil {
+		return Zero(), rest, err
+	}
+	return FromWords(w), rest, nil
+}
@@ -0,0 +1,62 @@
+package uint128
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestRLP unit tests for RLP encoding
+func TestRLP(t *testing.T) {
+	t.Run("manual", func(t *testing.T) {
+		tests := map[Uint128][]byte{
+			Zero():       {0x80},
+			One():        {0x01},
+			From64(0x7f): {0x7f},
+			From64(0x80): {0x81, 0x80},
+			Max():        append([]byte{0x80 + 16}, bytes.Repeat([]byte{0xff}, 16)...),
+		}
+		for x, expected := range tests {
+			var buf bytes.Buffer
+			if err := x.EncodeRLP(&buf); err != nil || !bytes.Equal(buf.Bytes(), expected) {
+				t.Errorf("EncodeRLP(%v) mismatch:\n\t(-) expected %x\n\t(+)   actual %x", x, expected, buf.Bytes())
+			}
+		}
+
+		for _, b := range [][]byte{
+			{},                 // empty
+			{0x00},             // zero is the empty string
+			{0x81, 0x01},       // single byte is its own encoding
+			{0x82, 0x00, 0x01}, // leading zeros
+			{0x82, 0x01},       // truncated
+			{0xc0},             // list
+			append([]byte{0x80 + 16 + 1}, bytes.Repeat([]byte{0xff}, 16+1)...), // overflow
+		} {
+			if _, _, err := DecodeRLP(b); err == nil {
+				t.Errorf("DecodeRLP(%x) should fail", b)
+			}
+		}
+	})
+
+	t.Run("rand", func(t *testing.T) {
+		values := make(chan Uint128)
+		go generate128s(1000, values)
+		for x := range values {
+			enc := x.AppendRLP([]byte{0xc0})[1:]
+			if x.IsZero() {
+				if !bytes.Equal(enc, []byte{0x80}) {
+					t.Fatalf("AppendRLP(0) mismatch: %x", enc)
+				}
+			} else if b := x.Bytes(); len(b) == 1 && b[0] < 0x80 {
+				if !bytes.Equal(enc, b) {
+					t.Fatalf("AppendRLP(%#x) mismatch: %x", x, enc)
+				}
+			} else if !bytes.Equal(enc, append([]byte{0x80 + byte(len(b))}, b...)) {
+				t.Fatalf("AppendRLP(%#x) mismatch: %x", x, enc)
+			}
+
+			if u, rest, err := DecodeRLP(append(enc, 0x01)); err != nil || !u.Equals(x) || !bytes.Equal(rest, []byte{0x01}) {
+				t.Fatalf("DecodeRLP is not the inverse of AppendRLP for %#x, got %#x, %v", x, u, err)
+			}
+		}
+	})
+}
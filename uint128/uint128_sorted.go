@@ -0,0 +1,201 @@
+package uint128
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/piliming/bigz/internal/nat"
+)
+
+// Sorted sequences of values are compressed with delta plus varint
+// encoding: values are split into blocks of 128, every block starts with
+// the LEB128 value followed by LEB128 differences to the previous values.
+// The header has the number of values, values per block and the byte
+// length of every block, so any block can be decoded without the previous
+// ones. Dense sets of IDs take a few bytes per value instead of 16.
+
+// AppendSorted appends the compressed encoding of ascending values to dst.
+// Duplicate values are allowed, unsorted values are an error.
+func AppendSorted(dst []byte, values []Uint128) ([]byte, error) {
+	blocks := make([]int, 0, (len(values)+nat.SortedBlockLen-1)/nat.SortedBlockLen)
+	var body []byte
+	start := 0
+	for i, x := range values {
+		if i > 0 && x.Cmp(values[i-1]) < 0 {
+			return dst, fmt.Errorf("sorted: value #%d is less than the previous one", i)
+		}
+		if i%nat.SortedBlockLen == 0 {
+			if i > 0 {
+				blocks = append(blocks, len(body)-start)
+				start = len(body)
+			}
+			body = x.AppendUvarint(body)
+			continue
+		}
+		body = x.Sub(values[i-1]).AppendUvarint(body)
+	}
+	if len(values) > 0 {
+		blocks = append(blocks, len(body)-start)
+	}
+	dst = nat.AppendSortedHeader(dst, len(values), nat.SortedBlockLen, blocks)
+	return append(dst, body...), nil
+}
+
+// DecodeSorted decodes all values of the AppendSorted encoding.
+func DecodeSorted(data []byte) ([]Uint128, error) {
+	s, err := ParseSorted(data)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]Uint128, 0, s.Len())
+	for it := s.Iter(); it.Next(); {
+		values = append(values, it.Value())
+	}
+	return values, nil
+}
+
+// Sorted is a read-only view of the AppendSorted encoding
+// with random access and iteration without decoding all values.
+type Sorted struct {
+	data     []byte
+	count    int
+	blockLen int
+	offsets  []int     // block boundaries in data
+	heads    []Uint128 // the first value of every block
+}
+
+// ParseSorted validates the AppendSorted encoding and returns its view.
+// The data is referenced, not copied, and must not be modified.
+// Truncated data, invalid varints, unsorted values and values
+// overflowing 128-bit are rejected.
+func ParseSorted(data []byte) (*Sorted, error) {
+	count, blockLen, offsets, err := nat.ParseSortedHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	s := &Sorted{
+		data:     data,
+		count:    count,
+		blockLen: blockLen,
+		offsets:  offsets,
+		heads:    make([]Uint128, len(offsets)-1),
+	}
+	var prev Uint128
+	for k := range s.heads {
+		b := data[offsets[k]:offsets[k+1]]
+		n := blockLen
+		if k == len(s.heads)-1 {
+			n = count - k*blockLen
+		}
+		for i := 0; i < n; i++ {
+			x, rest, err := DecodeUvarint(b)
+			if err != nil {
+				return nil, fmt.Errorf("sorted: block %d: %w", k, err)
+			}
+			b = rest
+			if i == 0 {
+				if k > 0 && x.Cmp(prev) < 0 {
+					return nil, fmt.Errorf("sorted: block %d is less than the previous one", k)
+				}
+				s.heads[k] = x
+			} else if x = prev.Add(x); x.Cmp(prev) < 0 {
+				return nil, fmt.Errorf("sorted: block %d: value overflows 128-bit", k)
+			}
+			prev = x
+		}
+		if len(b) != 0 {
+			return nil, fmt.Errorf("sorted: block %d: %d bytes of extraneous data", k, len(b))
+		}
+	}
+	return s, nil
+}
+
+// Len returns the number of values.
+func (s *Sorted) Len() int {
+	return s.count
+}
+
+// At returns the i-th value decoding at most one block.
+// It panics if i is out of range.
+func (s *Sorted) At(i int) Uint128 {
+	if i < 0 || i >= s.count {
+		panic(fmt.Sprintf("sorted: index %d out of range [0:%d]", i, s.count))
+	}
+	it := s.iterBlock(i / s.blockLen)
+	for j := i % s.blockLen; j >= 0; j-- {
+		it.Next()
+	}
+	return it.Value()
+}
+
+// Search returns the index of the first value not less than x and whether
+// x is found, like slices.BinarySearch. It decodes at most one block.
+func (s *Sorted) Search(x Uint128) (int, bool) {
+	k := sort.Search(len(s.heads), func(k int) bool { return s.heads[k].Cmp(x) >= 0 })
+	if k > 0 {
+		// the previous block may have values from its head up to x
+		it := s.iterBlock(k - 1)
+		for i := (k - 1) * s.blockLen; i < k*s.blockLen && it.Next(); i++ {
+			if c := it.Value().Cmp(x); c >= 0 {
+				return i, c == 0
+			}
+		}
+	}
+	if k < len(s.heads) {
+		return k * s.blockLen, s.heads[k] == x
+	}
+	return s.count, false
+}
+
+// Iter returns the iterator over all values in ascending order.
+func (s *Sorted) Iter() *SortedIter {
+	if len(s.heads) == 0 {
+		return &SortedIter{}
+	}
+	return s.iterBlock(0)
+}
+
+// iterBlock returns the iterator from the start of block k to the end.
+func (s *Sorted) iterBlock(k int) *SortedIter {
+	return &SortedIter{
+		data:  s.data[s.offsets[k]:s.offsets[len(s.offsets)-1]],
+		left:  s.count - k*s.blockLen,
+		block: s.blockLen,
+	}
+}
+
+// SortedIter iterates over values of Sorted:
+//
+//	for it := s.Iter(); it.Next(); {
+//		x := it.Value()
+//	}
+type SortedIter struct {
+	data  []byte  // encoded values left
+	left  int     // number of values left
+	block int     // values per block
+	i     int     // index of the next value in its block
+	value Uint128 // the current value
+}
+
+// Next advances to the next value and reports whether there is one.
+func (it *SortedIter) Next() bool {
+	if it.left == 0 {
+		return false
+	}
+	x, rest, _ := DecodeUvarint(it.data) // validated by ParseSorted
+	if it.i == 0 {
+		it.value = x
+	} else {
+		it.value = it.value.Add(x)
+	}
+	it.data, it.left = rest, it.left-1
+	if it.i++; it.i == it.block {
+		it.i = 0
+	}
+	return true
+}
+
+// Value returns the current value.
+func (it *SortedIter) Value() Uint128 {
+	return it.value
+}
@@ -0,0 +1,112 @@
+package uint128
+
+import (
+	"encoding/hex"
+	"sort"
+	"testing"
+)
+
+// TestSorted unit tests for the sorted sequence codec
+func TestSorted(t *testing.T) {
+	t.Run("manual", func(t *testing.T) {
+		tests := []struct {
+			values   []Uint128
+			expected string
+		}{
+			{nil, "008001"},
+			{[]Uint128{From64(5)}, "01800101" + "05"},
+			{[]Uint128{From64(1), From64(3), From64(3), From64(300)}, "04800105" + "01" + "02" + "00" + "a902"},
+		}
+		for _, tt := range tests {
+			data, err := AppendSorted(nil, tt.values)
+			if got := hex.EncodeToString(data); err != nil || got != tt.expected {
+				t.Errorf("AppendSorted(%v) mismatch:\n\t(-) expected %s\n\t(+)   actual %s, %v", tt.values, tt.expected, got, err)
+			}
+			got, err := DecodeSorted(data)
+			if err != nil || len(got) != len(tt.values) {
+				t.Fatalf("DecodeSorted(%x) failed: %v, %v", data, got, err)
+			}
+			for i := range got {
+				if got[i] != tt.values[i] {
+					t.Errorf("DecodeSorted(%x) mismatch: %v", data, got)
+				}
+			}
+		}
+
+		if _, err := AppendSorted(nil, []Uint128{From64(2), From64(1)}); err == nil {
+			t.Errorf("AppendSorted of unsorted values should fail")
+		}
+		unsorted := make([]Uint128, 130)
+		for i := range unsorted {
+			unsorted[i] = From64(uint64(i))
+		}
+		unsorted[128] = From64(5) // the head of the second block
+		if _, err := AppendSorted(nil, unsorted); err == nil {
+			t.Errorf("AppendSorted of unsorted values at block boundary should fail")
+		}
+
+		max := hex.EncodeToString(Max().AppendUvarint(nil))
+		for _, s := range []string{
+			"",
+			"01",
+			"0100",                   // zero values per block
+			"018001",                 // truncated
+			"01800102" + "0500",      // extraneous data
+			"02800101" + "05",        // fewer values
+			"02800102" + "0580",      // truncated varint
+			"02800101" + "05" + "01", // length mismatch
+			"02800114" + max + "01",  // overflow
+			"02010101" + "05" + "04", // unsorted blocks
+			"ff01",
+		} {
+			b, _ := hex.DecodeString(s)
+			if _, err := ParseSorted(b); err == nil {
+				t.Errorf("ParseSorted(%s) should fail", s)
+			}
+		}
+	})
+
+	t.Run("rand", func(t *testing.T) {
+		for _, n := range []int{1, 127, 128, 129, 1000} {
+			values := rand128slice(n)
+			for i := range values {
+				values[i] = values[i].Rsh(uint(64 + i%64)) // various deltas
+				if i%7 == 0 && i > 0 {
+					values[i] = values[i-1] // duplicates
+				}
+			}
+			sort.Slice(values, func(i, j int) bool { return values[i].Cmp(values[j]) < 0 })
+
+			data, err := AppendSorted(nil, values)
+			if err != nil {
+				t.Fatalf("AppendSorted failed: %v", err)
+			}
+			s, err := ParseSorted(data)
+			if err != nil || s.Len() != n {
+				t.Fatalf("ParseSorted failed: %v", err)
+			}
+			i := 0
+			for it := s.Iter(); it.Next(); i++ {
+				if it.Value() != values[i] {
+					t.Fatalf("Iter value #%d mismatch: expected %v, got %v", i, values[i], it.Value())
+				}
+			}
+			if i != n {
+				t.Fatalf("Iter count mismatch: expected %d, got %d", n, i)
+			}
+
+			for i, x := range values {
+				if got := s.At(i); got != x {
+					t.Fatalf("At(%d) mismatch: expected %v, got %v", i, x, got)
+				}
+				for _, y := range []Uint128{x, x.Add64(1), x.Sub64(1)} {
+					expected := sort.Search(n, func(k int) bool { return values[k].Cmp(y) >= 0 })
+					found := expected < n && values[expected] == y
+					if got, ok := s.Search(y); got != expected || ok != found {
+						t.Fatalf("Search(%v) mismatch: expected %d %t, got %d %t", y, expected, found, got, ok)
+					}
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package uint128
+
+import (
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/piliming/bigz/internal/nat"
+)
+
+// Uint128 cannot implement sql.Scanner itself because Scan is already
+// taken by fmt.Scanner. Use Numeric or FixedBytes to scan values:
+//
+//	var u Uint128
+//	err := row.Scan((*Numeric)(&u))
+
+// Value implements the driver.Valuer interface.
+// The value is the base-10 text, e.g. for NUMERIC(39,0) columns.
+func (u Uint128) Value() (driver.Value, error) {
+	return u.String(), nil
+}
+
+// Numeric is a Uint128 stored as decimal text, e.g. in NUMERIC columns.
+type Numeric Uint128
+
+// Value implements the driver.Valuer interface.
+func (u Numeric) Value() (driver.Value, error) {
+	return Uint128(u).String(), nil
+}
+
+// Scan implements the sql.Scanner interface. It accepts int64, uint64
+// and decimal string or []byte, zero fraction like "123.000" is allowed.
+// NULL, negative, non-integer and overflowing values are rejected.
+func (u *Numeric) Scan(src interface{}) error {
+	var w [2]uint64
+	if err := nat.ScanSQL(w[:], src); err != nil {
+		return fmt.Errorf("cannot scan %T into 128-bit integer: %w", src, err)
+	}
+	*u = Numeric(FromWords(w))
+	return nil
+}
+
+// FixedBytes is a Uint128 stored as 16 bytes in big-endian byte order,
+// e.g. in BYTEA or BINARY(16) columns.
+type FixedBytes Uint128
+
+// Value implements the driver.Valuer interface.
+func (u FixedBytes) Value() (driver.Value, error) {
+	return Uint128(u).MarshalBinary()
+}
+
+// Scan implements the sql.Scanner interface.
+// It accepts []byte of exactly 16 bytes only.
+func (u *FixedBytes) Scan(src interface{}) error {
+	b, ok := src.([]byte)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into 128-bit integer, expected []byte", src)
+	}
+	return (*Uint128)(u).UnmarshalBinary(b)
+}
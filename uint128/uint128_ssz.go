@@ -0,0 +1,41 @@
+package uint128
+
+import "fmt"
+
+// SSZ (SimpleSerialize) encoding of uint128 basic type: 16 bytes in
+// little-endian byte order. The methods match the interfaces used by
+// fastssz generated code (ssz.Marshaler, ssz.Unmarshaler, ssz.HashRoot).
+
+// SizeSSZ returns the size of SSZ encoding, always 16 bytes.
+func (u Uint128) SizeSSZ() int {
+	return 16
+}
+
+// MarshalSSZ returns the SSZ encoding of u.
+func (u Uint128) MarshalSSZ() ([]byte, error) {
+	return u.MarshalSSZTo(make([]byte, 0, 16))
+}
+
+// MarshalSSZTo appends the SSZ encoding of u to dst.
+func (u Uint128) MarshalSSZTo(dst []byte) ([]byte, error) {
+	dst = append(dst, make([]byte, 16)...)
+	StoreLittleEndian(dst[len(dst)-16:], u)
+	return dst, nil
+}
+
+// UnmarshalSSZ decodes u from SSZ encoding, which must be exactly 16 bytes.
+func (u *Uint128) UnmarshalSSZ(buf []byte) error {
+	if len(buf) != 16 {
+		return fmt.Errorf("invalid uint128 SSZ size %d, expected 16", len(buf))
+	}
+	*u = LoadLittleEndian(buf)
+	return nil
+}
+
+// HashTreeRoot returns the SSZ hash tree root of u. For a basic type it is
+// the single 32-byte chunk: the little-endian encoding padded with zeros.
+func (u Uint128) HashTreeRoot() ([32]byte, error) {
+	var root [32]byte
+	StoreLittleEndian(root[:16], u)
+	return root, nil
+}
@@ -0,0 +1,55 @@
+package uint128
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestSSZ unit tests for SSZ encoding
+func TestSSZ(t *testing.T) {
+	t.Run("manual", func(t *testing.T) {
+		// 0x0102 is 02 01 00 ... in little-endian
+		expected := make([]byte, 16)
+		expected[0], expected[1] = 0x02, 0x01
+		if got, err := From64(0x0102).MarshalSSZ(); err != nil || !bytes.Equal(got, expected) {
+			t.Errorf("MarshalSSZ mismatch:\n\t(-) expected %x\n\t(+)   actual %x", expected, got)
+		}
+		if root, err := From64(0x0102).HashTreeRoot(); err != nil || !bytes.Equal(root[:], append(expected, make([]byte, 32-16)...)) {
+			t.Errorf("HashTreeRoot mismatch: %x", root)
+		}
+
+		var u Uint128
+		for _, n := range []int{0, 16 - 1, 16 + 1} {
+			if err := u.UnmarshalSSZ(make([]byte, n)); err == nil {
+				t.Errorf("UnmarshalSSZ(%d bytes) should fail", n)
+			}
+		}
+	})
+
+	t.Run("rand", func(t *testing.T) {
+		values := make(chan Uint128)
+		go generate128s(1000, values)
+		for x := range values {
+			if x.SizeSSZ() != 16 {
+				t.Fatalf("SizeSSZ should be 16, got %d", x.SizeSSZ())
+			}
+
+			buf, err := x.MarshalSSZTo([]byte("foo"))
+			if err != nil || len(buf) != 3+16 || string(buf[:3]) != "foo" {
+				t.Fatalf("MarshalSSZTo(%#x) mismatch: %x, %v", x, buf, err)
+			}
+			var u Uint128
+			if err := u.UnmarshalSSZ(buf[3:]); err != nil || !u.Equals(x) {
+				t.Fatalf("UnmarshalSSZ is not the inverse of MarshalSSZTo for %#x, got %#x, %v", x, u, err)
+			}
+			if expected := x.ReverseBytes().FillBytes(make([]byte, 16)); !bytes.Equal(buf[3:], expected) {
+				t.Fatalf("MarshalSSZTo(%#x) should be little-endian: %x", x, buf[3:])
+			}
+
+			root, err := x.HashTreeRoot()
+			if err != nil || !bytes.Equal(root[:16], buf[3:]) || !bytes.Equal(root[16:], make([]byte, 32-16)) {
+				t.Fatalf("HashTreeRoot(%#x) mismatch: %x", x, root)
+			}
+		}
+	})
+}
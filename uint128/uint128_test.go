@@ -75,6 +75,15 @@ func TestUint128Helpers(t *testing.T) {
 				t.Fatalf("FromBig is not the inverse of Big for #%x, got %#x", x, got)
 			}
 
+			if got := FromWords(x.Words()); got != x {
+				t.Fatalf("FromWords is not the inverse of Words for %#x, got %#x", x, got)
+			}
+			for i, w := range x.Words() {
+				if expected := new(big.Int).Rsh(x.Big(), uint(64*i)).Uint64(); w != expected {
+					t.Fatalf("%#x Words()[%d] should equal %#x, got %#x", x, i, expected, w)
+				}
+			}
+
 			if !x.Equals(x) {
 				t.Fatalf("%#x does not equal itself", x)
 			}
@@ -115,6 +124,11 @@ func TestUint128Bits(t *testing.T) {
 			if expected, got := x.Big().BitLen(), x.BitLen(); expected != got {
 				t.Fatalf("mismatch: %#x BitLen should equal %v, got %v", x, expected, got)
 			}
+			for i := -1; i <= 128; i++ {
+				if expected, got := x.Big().Bit(i&255) == 1 && i >= 0, x.Bit(i); expected != got {
+					t.Fatalf("mismatch: %#x Bit(%d) should equal %v, got %v", x, i, expected, got)
+				}
+			}
 		}
 	})
 }
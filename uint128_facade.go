@@ -1,6 +1,8 @@
 package bigz
 
 import (
+	"math/big"
+
 	u128 "github.com/piliming/bigz/uint128"
 )
 
@@ -25,3 +27,25 @@ func One128() Uint128 {
 func Max128() Uint128 {
 	return u128.Max()
 }
+
+// From64To128 converts 64-bit value v to a Uint128 value.
+func From64To128(v uint64) Uint128 {
+	return u128.From64(v)
+}
+
+// FromBig128 converts *big.Int to Uint128 value with saturation.
+// See FromBigEx128 to detect overflows.
+func FromBig128(i *big.Int) Uint128 {
+	return u128.FromBig(i)
+}
+
+// FromBigEx128 converts *big.Int to Uint128 value (eXtended version).
+// Provides ok successful flag as a second return value.
+func FromBigEx128(i *big.Int) (Uint128, bool) {
+	return u128.FromBigEx(i)
+}
+
+// Parse128 parses input string as a Uint128 value.
+func Parse128(s string) (Uint128, error) {
+	return u128.FromString(s)
+}
@@ -349,3 +349,25 @@ func BenchmarkLsh(b *testing.B) {
 	})
 
 }
+
+// BenchmarkShift performance tests for Lsh and Rsh.
+func BenchmarkShift(b *testing.B) {
+	const K = 1024 // should be power of 2
+	xx := rand256slice(K)
+
+	// Uint256: 256 << n
+	b.Run("Uint256_Lsh", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			res := xx[i%K].Lsh(uint(i % 260))
+			DummyOutput += int(res.Lo.Lo & 1)
+		}
+	})
+
+	// Uint256: 256 >> n
+	b.Run("Uint256_Rsh", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			res := xx[i%K].Rsh(uint(i % 260))
+			DummyOutput += int(res.Lo.Lo & 1)
+		}
+	})
+}
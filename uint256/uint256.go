@@ -4,7 +4,6 @@ import (
 	"errors"
 	"math/big"
 	"math/bits"
-	"unsafe"
 
 	"github.com/piliming/bigz/uint128"
 )
@@ -102,6 +101,22 @@ func (u Uint256) Big() *big.Int {
 	return i
 }
 
+// Words returns 256-bit value as an array of 64-bit limbs
+// in little-endian order, i.e. Words()[0] is the least significant limb.
+// The layout is compatible with [4]uint64 used by holiman/uint256.
+func (u Uint256) Words() [4]uint64 {
+	return [4]uint64{u.Lo.Lo, u.Lo.Hi, u.Hi.Lo, u.Hi.Hi}
+}
+
+// FromWords converts an array of 64-bit limbs in little-endian order
+// (the least significant limb goes first) to a Uint256 value.
+func FromWords(w [4]uint64) Uint256 {
+	return Uint256{
+		Lo: Uint128{Lo: w[0], Hi: w[1]},
+		Hi: Uint128{Lo: w[2], Hi: w[3]},
+	}
+}
+
 // IsZero returns true if stored 256-bit value is zero.
 func (u Uint256) IsZero() bool {
 	return u.Lo.IsZero() && u.Hi.IsZero()
@@ -473,63 +488,11 @@ func (u Uint256) Lsh(n uint) Uint256 {
 	}
 }
 
+// Rsh2 returns right shift (u>>n).
+//
+// Deprecated: use Rsh instead.
 func (u Uint256) Rsh2(n uint) Uint256 {
-	if n == 0 {
-		return u
-	}
-	if n >= 512 {
-		return Zero()
-	}
-
-	uArr := *(*[4]uint64)(unsafe.Pointer(&u))
-
-	var result [4]uint64
-
-	if n < 64 {
-		result[0] = (uArr[0] >> n) | (uArr[1] << (64 - n))
-		result[1] = (uArr[1] >> n) | (uArr[2] << (64 - n))
-		result[2] = (uArr[2] >> n) | (uArr[3] << (64 - n))
-		result[3] = (uArr[3] >> n)
-
-		return *(*Uint256)(unsafe.Pointer(&result[0]))
-	}
-
-	if n < 128 {
-		rshiftBits := n - 64
-		lshiftBits := 128 - n
-
-		result[0] = (uArr[1] >> rshiftBits) | (uArr[2] << lshiftBits)
-		result[1] = (uArr[2] >> rshiftBits) | (uArr[3] << lshiftBits)
-		result[2] = (uArr[3] >> rshiftBits)
-		result[3] = 0
-
-		return *(*Uint256)(unsafe.Pointer(&result[0]))
-	}
-
-	if n < 192 {
-		rshiftBits := n - 128
-		lshiftBits := 192 - n
-
-		result[0] = (uArr[2] >> rshiftBits) | (uArr[3] << lshiftBits)
-		result[1] = (uArr[3] >> rshiftBits)
-		result[2] = 0
-		result[3] = 0
-
-		return *(*Uint256)(unsafe.Pointer(&result[0]))
-	}
-	if n < 256 {
-		rshiftBits := n - 192
-		//lshiftBits := 256 - n
-
-		result[0] = (uArr[3] >> rshiftBits)
-		result[1] = 0
-		result[2] = 0
-		result[3] = 0
-
-		return *(*Uint256)(unsafe.Pointer(&result[0]))
-	}
-
-	return Zero()
+	return u.Rsh(n)
 }
 
 // Rsh returns right shift (u>>n).
@@ -726,3 +689,12 @@ func (u Uint256) ReverseBytes() Uint256 {
 		Hi: u.Lo.ReverseBytes(),
 	}
 }
+
+// Bit returns true if the n-th bit of value is set, i.e. (u>>n)&1 == 1.
+// The result is false if n is out of [0, 256) range.
+func (u Uint256) Bit(n int) bool {
+	if n < 0 || n >= 256 {
+		return false
+	}
+	return (u.Words()[n/64]>>uint(n%64))&1 == 1
+}
@@ -0,0 +1,97 @@
+package uint256
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/piliming/bigz/alphabet"
+)
+
+// TestBase unit tests for custom alphabet encoding
+func TestBase(t *testing.T) {
+	alphabets := []*alphabet.Alphabet{alphabet.Base58, alphabet.Crockford32, alphabet.Base36, alphabet.Base62}
+
+	t.Run("manual", func(t *testing.T) {
+		tests := []struct {
+			a        *alphabet.Alphabet
+			x        Uint256
+			expected string
+		}{
+			{alphabet.Base58, Zero(), "1"},
+			{alphabet.Base58, From64(57), "z"},
+			{alphabet.Base58, From64(58), "21"},
+			{alphabet.Crockford32, From64(32), "10"},
+			{alphabet.Base36, From64(35), "z"},
+			{alphabet.Base62, From64(61), "z"},
+			{alphabet.Base62, From64(62), "10"},
+		}
+		for _, tt := range tests {
+			if got := EncodeBase(tt.x, tt.a); got != tt.expected {
+				t.Errorf("EncodeBase(%v, %s) mismatch:\n\t(-) expected %s\n\t(+)   actual %s", tt.x, tt.a, tt.expected, got)
+			}
+			if got, err := DecodeBase(tt.expected, tt.a); err != nil || got != tt.x {
+				t.Errorf("DecodeBase(%s, %s) mismatch: %v, %v", tt.expected, tt.a, got, err)
+			}
+		}
+
+		// aliases
+		if got, err := DecodeBase("1o-", alphabet.Crockford32); err == nil {
+			t.Errorf("DecodeBase(1o-) should fail, got %v", got)
+		}
+		if got, err := DecodeBase("iLo", alphabet.Crockford32); err != nil || got != From64(32*32+32) {
+			t.Errorf("DecodeBase(iLo) mismatch: %v, %v", got, err)
+		}
+
+		for _, a := range alphabets {
+			padded := EncodeBasePadded(Max(), a)
+			if EncodeBase(Max(), a) != padded || strings.Trim(EncodeBasePadded(Zero(), a), a.String()[:1]) != "" {
+				t.Errorf("%s: padded encoding should have the length of Max()", a)
+			}
+			for _, s := range []string{"", "-", padded + a.String()[:1], strings.Repeat(a.String()[a.Radix()-1:], len(padded)) + "\x00"} {
+				if _, err := DecodeBase(s, a); !errors.Is(err, strconv.ErrSyntax) {
+					t.Errorf("%s: DecodeBase(%q) should fail with syntax error, got %v", a, s, err)
+				}
+			}
+			if max := strings.Repeat(a.String()[a.Radix()-1:], len(padded)); max != padded {
+				if _, err := DecodeBase(max, a); !errors.Is(err, strconv.ErrRange) {
+					t.Errorf("%s: DecodeBase(%s) should fail with range error, got %v", a, max, err)
+				}
+			}
+		}
+	})
+
+	t.Run("rand", func(t *testing.T) {
+		var bytes255 []byte // the largest alphabet, bytes 0x01 to 0xff
+		for c := 1; c < 256; c++ {
+			bytes255 = append(bytes255, byte(c))
+		}
+
+		values := rand256slice(1000)
+		for _, a := range append(alphabets, alphabet.MustNew(string(bytes255))) {
+			var encoded []string
+			for _, x := range values {
+				s := EncodeBasePadded(x, a)
+				if got, err := DecodeBase(s, a); err != nil || got != x {
+					t.Fatalf("%s does not equal itself after decoding, got: %v, %v", s, got, err)
+				}
+				if got, err := DecodeBase(EncodeBase(x, a), a); err != nil || got != x {
+					t.Fatalf("%v does not equal itself after decoding, got: %v, %v", x, got, err)
+				}
+				encoded = append(encoded, s)
+			}
+
+			// padded encodings sort as values
+			sort.Strings(encoded)
+			for i := 1; i < len(encoded); i++ {
+				x, _ := DecodeBase(encoded[i-1], a)
+				y, _ := DecodeBase(encoded[i], a)
+				if x.Cmp(y) > 0 {
+					t.Fatalf("%s: %s > %s", a, encoded[i-1], encoded[i])
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,158 @@
+package uint256
+
+import (
+	"fmt"
+	"io"
+)
+
+// The binary layout of Uint256 is fixed: 32 bytes in big-endian byte order,
+// i.e. the most significant byte goes first. The compact layout is the same
+// but without leading zero bytes, just like (*big.Int).Bytes.
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// The result is always 32 bytes in big-endian byte order.
+func (u Uint256) MarshalBinary() ([]byte, error) {
+	return u.AppendBinary(make([]byte, 0, 32))
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+// The data must be exactly 32 bytes in big-endian byte order.
+func (u *Uint256) UnmarshalBinary(data []byte) error {
+	if len(data) != 32 {
+		return fmt.Errorf("invalid 256-bit binary length %d, expected 32", len(data))
+	}
+	*u = LoadBigEndian(data)
+	return nil
+}
+
+// GobEncode implements the gob.GobEncoder interface.
+// The layout is the same as of MarshalBinary.
+func (u Uint256) GobEncode() ([]byte, error) {
+	return u.MarshalBinary()
+}
+
+// GobDecode implements the gob.GobDecoder interface.
+// The layout is the same as of UnmarshalBinary.
+func (u *Uint256) GobDecode(data []byte) error {
+	return u.UnmarshalBinary(data)
+}
+
+// Bytes returns the compact big-endian representation of u,
+// i.e. without leading zero bytes. The result is empty for zero.
+func (u Uint256) Bytes() []byte {
+	var buf [32]byte
+	StoreBigEndian(buf[:], u)
+	i := 0
+	for i < len(buf) && buf[i] == 0 {
+		i++
+	}
+	return append([]byte{}, buf[i:]...)
+}
+
+// FromBytes converts the big-endian byte slice of any length to Uint256.
+// Leading zero bytes are ignored, so it is the inverse of Bytes and
+// of MarshalBinary. If the value overflows 256-bit then ok=false
+// and Max() is returned.
+func FromBytes(b []byte) (Uint256, bool) {
+	for len(b) > 0 && b[0] == 0 {
+		b = b[1:]
+	}
+	if len(b) > 32 {
+		return Max(), false // value overflows 256-bit!
+	}
+	var buf [32]byte
+	copy(buf[32-len(b):], b)
+	return LoadBigEndian(buf[:]), true
+}
+
+// FillBytes sets buf to u, storing it as a zero-extended big-endian byte slice,
+// and returns buf. Just like (*big.Int).FillBytes, buf may be shorter than 32
+// bytes if the value fits, otherwise it panics.
+func (u Uint256) FillBytes(buf []byte) []byte {
+	var tmp [32]byte
+	StoreBigEndian(tmp[:], u)
+	if n := len(tmp) - len(buf); n > 0 {
+		for _, c := range tmp[:n] {
+			if c != 0 {
+				panic("uint256: buffer too small to fit value")
+			}
+		}
+		copy(buf, tmp[n:])
+	} else {
+		for i := range buf[:-n] {
+			buf[i] = 0
+		}
+		copy(buf[-n:], tmp[:])
+	}
+	return buf
+}
+
+// SetBytes sets u to the value of big-endian byte slice of any length
+// and reports whether there is no overflow. It is the same as FromBytes.
+func (u *Uint256) SetBytes(b []byte) (ok bool) {
+	*u, ok = FromBytes(b)
+	return ok
+}
+
+// StoreLittleEndianArray stores 256-bit value in byte array in little-endian byte order.
+func StoreLittleEndianArray(b *[32]byte, u Uint256) {
+	StoreLittleEndian(b[:], u)
+}
+
+// StoreBigEndianArray stores 256-bit value in byte array in big-endian byte order.
+func StoreBigEndianArray(b *[32]byte, u Uint256) {
+	StoreBigEndian(b[:], u)
+}
+
+// LoadLittleEndianArray loads 256-bit value from byte array in little-endian byte order.
+func LoadLittleEndianArray(b *[32]byte) Uint256 {
+	return LoadLittleEndian(b[:])
+}
+
+// LoadBigEndianArray loads 256-bit value from byte array in big-endian byte order.
+func LoadBigEndianArray(b *[32]byte) Uint256 {
+	return LoadBigEndian(b[:])
+}
+
+// StoreLittleEndianChecked stores 256-bit value in byte slice in little-endian byte order.
+// Unlike StoreLittleEndian it returns an error if byte slice length is less than 32.
+func StoreLittleEndianChecked(b []byte, u Uint256) error {
+	if len(b) < 32 {
+		return shortBufferError(len(b))
+	}
+	StoreLittleEndian(b, u)
+	return nil
+}
+
+// StoreBigEndianChecked stores 256-bit value in byte slice in big-endian byte order.
+// Unlike StoreBigEndian it returns an error if byte slice length is less than 32.
+func StoreBigEndianChecked(b []byte, u Uint256) error {
+	if len(b) < 32 {
+		return shortBufferError(len(b))
+	}
+	StoreBigEndian(b, u)
+	return nil
+}
+
+// LoadLittleEndianChecked loads 256-bit value from byte slice in little-endian byte order.
+// Unlike LoadLittleEndian it returns an error if byte slice length is less than 32.
+func LoadLittleEndianChecked(b []byte) (Uint256, error) {
+	if len(b) < 32 {
+		return Zero(), shortBufferError(len(b))
+	}
+	return LoadLittleEndian(b), nil
+}
+
+// LoadBigEndianChecked loads 256-bit value from byte slice in big-endian byte order.
+// Unlike LoadBigEndian it returns an error if byte slice length is less than 32.
+func LoadBigEndianChecked(b []byte) (Uint256, error) {
+	if len(b) < 32 {
+		return Zero(), shortBufferError(len(b))
+	}
+	return LoadBigEndian(b), nil
+}
+
+// shortBufferError returns io.ErrShortBuffer based error.
+func shortBufferError(n int) error {
+	return fmt.Errorf("%d bytes is not enough for 256-bit integer: %w", n, io.ErrShortBuffer)
+}
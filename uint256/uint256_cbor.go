@@ -0,0 +1,52 @@
+package uint256
+
+import (
+	"fmt"
+
+	"github.com/piliming/bigz/internal/nat"
+)
+
+// CBOR (RFC 8949) encoding uses the shortest form: unsigned integer
+// (major type 0) if the value fits 64 bits, otherwise positive bignum
+// (tag 2) around the byte string of big-endian bytes without leading zeros.
+//
+// MarshalCBOR and UnmarshalCBOR implement the fxamacker/cbor Marshaler
+// and Unmarshaler interfaces.
+
+// AppendCBOR appends the shortest CBOR encoding of u to dst.
+func (u Uint256) AppendCBOR(dst []byte) []byte {
+	w := u.Words()
+	return nat.AppendCBOR(dst, w[:])
+}
+
+// MarshalCBOR returns the shortest CBOR encoding of u.
+func (u Uint256) MarshalCBOR() ([]byte, error) {
+	return u.AppendCBOR(make([]byte, 0, 35)), nil
+}
+
+// UnmarshalCBOR decodes the CBOR data item which must be the whole data.
+// See DecodeCBOR for accepted encodings.
+func (u *Uint256) UnmarshalCBOR(data []byte) error {
+	x, rest, err := DecodeCBOR(data)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 0 {
+		return fmt.Errorf("cbor: %d bytes of extraneous data", len(rest))
+	}
+	*u = x
+	return nil
+}
+
+// DecodeCBOR decodes CBOR unsigned integer or positive bignum (tag 2) from
+// the front of b and returns it with the rest of b. Non-shortest forms are
+// accepted. Negative values, values overflowing 256-bit and other data
+// items are rejected.
+func DecodeCBOR(b []byte) (Uint256, []byte, error) {
+	var w [4]uint64
+	rest, err := nat.DecodeCBOR(w[:], b)
+	if err != nil {
+		return Zero(), rest, err
+	}
+	return FromWords(w), rest, nil
+}
@@ -0,0 +1,66 @@
+package uint256
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+// TestCBOR unit tests for CBOR encoding
+func TestCBOR(t *testing.T) {
+	t.Run("manual", func(t *testing.T) {
+		tests := map[Uint256]string{
+			Zero():            "00",
+			From64(23):        "17",
+			From64(24):        "1818",
+			From64(1000):      "1903e8",
+			From64(1<<64 - 1): "1bffffffffffffffff",
+			One().Lsh(64):     "c249010000000000000000",
+		}
+		for x, expected := range tests {
+			if got, err := x.MarshalCBOR(); err != nil || hex.EncodeToString(got) != expected {
+				t.Errorf("MarshalCBOR(%v) mismatch:\n\t(-) expected %s\n\t(+)   actual %x", x, expected, got)
+			}
+		}
+
+		var u Uint256
+		for _, s := range []string{
+			"",       // empty
+			"20",     // negative
+			"c34101", // negative bignum
+			"f6",     // null
+			"0000",   // extraneous data
+			"c25821" + "01" + strings.Repeat("00", 256/8), // overflow
+		} {
+			b, _ := hex.DecodeString(s)
+			if err := u.UnmarshalCBOR(b); err == nil {
+				t.Errorf("UnmarshalCBOR(%s) should fail", s)
+			}
+		}
+	})
+
+	t.Run("rand", func(t *testing.T) {
+		for _, x := range rand256slice(1000) {
+			x = x.Rsh(uint(x.Words()[0] % 256)) // more short values
+			got, _ := x.MarshalCBOR()
+			if b := x.Big(); !b.IsUint64() {
+				expected := append([]byte{0xc2, 0x40 + byte(len(b.Bytes()))}, b.Bytes()...)
+				if len(b.Bytes()) >= 24 {
+					expected = append([]byte{0xc2, 0x58, byte(len(b.Bytes()))}, b.Bytes()...)
+				}
+				if hex.EncodeToString(got) != hex.EncodeToString(expected) {
+					t.Fatalf("MarshalCBOR(%v) mismatch:\n\t(-) expected %x\n\t(+)   actual %x", x, expected, got)
+				}
+			}
+
+			var u Uint256
+			if err := u.UnmarshalCBOR(got); err != nil || !u.Equals(x) {
+				t.Fatalf("%x does not equal itself after CBOR decoding, got: %v, %v", got, u, err)
+			}
+			if u, rest, err := DecodeCBOR(append(got, 0x05)); err != nil || !u.Equals(x) || !bytes.Equal(rest, []byte{0x05}) {
+				t.Fatalf("DecodeCBOR(%x) mismatch: %v, rest %x, %v", got, u, rest, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package uint256
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"encoding/hex"
+	"math/big"
+	"testing"
+)
+
+// TestDER unit tests for ASN.1 DER encoding
+func TestDER(t *testing.T) {
+	t.Run("manual", func(t *testing.T) {
+		tests := map[Uint256]string{
+			Zero():        "020100",
+			From64(0x7f):  "02017f",
+			From64(0x80):  "02020080",
+			One().Lsh(64): "0209010000000000000000",
+		}
+		for x, expected := range tests {
+			if got, err := x.MarshalDER(); err != nil || hex.EncodeToString(got) != expected {
+				t.Errorf("MarshalDER(%v) mismatch:\n\t(-) expected %s\n\t(+)   actual %x", x, expected, got)
+			}
+		}
+
+		var u Uint256
+		for _, s := range []string{
+			"",         // empty
+			"0400",     // not INTEGER
+			"0201ff",   // negative
+			"02020001", // non-minimal
+			"02810101", // non-minimal length
+			"02010000", // trailing data
+			"02" + hex.EncodeToString([]byte{256/8 + 1, 1}) + hex.EncodeToString(make([]byte, 256/8)), // overflow
+		} {
+			b, _ := hex.DecodeString(s)
+			if err := u.UnmarshalDER(b); err == nil {
+				t.Errorf("UnmarshalDER(%s) should fail", s)
+			}
+		}
+
+		if _, err := FromRawValue(asn1.RawValue{Tag: asn1.TagOctetString, Bytes: []byte{1}}); err == nil {
+			t.Errorf("FromRawValue(OCTET STRING) should fail")
+		}
+	})
+
+	t.Run("asn1", func(t *testing.T) {
+		type Cert struct {
+			Serial asn1.RawValue
+			Name   string
+		}
+		for _, x := range rand256slice(1000) {
+			x = x.Rsh(uint(x.Words()[0] % 256)) // more short values
+			enc, err := asn1.Marshal(Cert{Serial: x.RawValue(), Name: "test"})
+			if err != nil {
+				t.Fatalf("asn1.Marshal failed: %v", err)
+			}
+			expected, _ := asn1.Marshal(struct {
+				Serial *big.Int
+				Name   string
+			}{x.Big(), "test"})
+			if hex.EncodeToString(enc) != hex.EncodeToString(expected) {
+				t.Fatalf("asn1.Marshal(%v) mismatch:\n\t(-) expected %x\n\t(+)   actual %x", x, expected, enc)
+			}
+
+			var cert Cert
+			if _, err := asn1.Unmarshal(enc, &cert); err != nil {
+				t.Fatalf("asn1.Unmarshal failed: %v", err)
+			}
+			if got, err := FromRawValue(cert.Serial); err != nil || !got.Equals(x) {
+				t.Fatalf("FromRawValue mismatch: %v, %v, expected %v", got, err, x)
+			}
+
+			var u Uint256
+			if err := u.UnmarshalDER(cert.Serial.FullBytes); err != nil || !u.Equals(x) {
+				t.Fatalf("%x does not equal itself after DER decoding, got: %v, %v", cert.Serial.FullBytes, u, err)
+			}
+			if u, rest, err := DecodeDER(enc[2:]); err != nil || !u.Equals(x) || !bytes.Equal(rest, enc[2+len(cert.Serial.FullBytes):]) {
+				t.Fatalf("DecodeDER(%x) mismatch: %v, rest %x, %v", enc[2:], u, rest, err)
+			}
+		}
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/piliming/bigz/internal/nat"
 	"github.com/piliming/bigz/uint128"
 )
 
@@ -14,33 +15,50 @@ func FromString(s string) (Uint256, error) {
 	return u, err
 }
 
+// ParseUint interprets a string s in the given base (0, 2 to 36) and
+// returns the corresponding 256-bit value. It works like strconv.ParseUint:
+// if base is 0, it is implied by the prefix (0b, 0o or 0, 0x) and
+// underscores are permitted as digit separators.
+//
+// The error is *strconv.NumError. On overflow, the result is Max()
+// and the error is strconv.ErrRange. There is no memory allocation
+// unless an error is returned.
+func ParseUint(s string, base int) (Uint256, error) {
+	var w [4]uint64
+	err := nat.ParseUint(w[:], s, base)
+	return FromWords(w), err
+}
+
+// FormatUint returns the string representation of u in the given base,
+// for 2 <= base <= 36. The result uses the lower-case letters 'a' to 'z'
+// for digit values >= 10. It panics if base is out of range.
+func FormatUint(u Uint256, base int) string {
+	w := u.Words()
+	return string(nat.AppendDigits(nil, w[:], base))
+}
+
+// AppendUint appends the string form of u, as generated by FormatUint,
+// to dst and returns the extended buffer.
+func AppendUint(dst []byte, u Uint256, base int) []byte {
+	w := u.Words()
+	return nat.AppendDigits(dst, w[:], base)
+}
+
 // String returns the base-10 representation of 256-bit value.
 func (u Uint256) String() string {
-	if u.Hi.IsZero() {
-		if u.Lo.IsZero() {
-			return "0" // zero
-		}
-		return u.Lo.String() // lower 128-bit
-	}
-
-	buf := []byte("000000000000000000000000000000000000000000000000000000000000000000000000000000") // log10(2^256) < 78
-	for i := len(buf); ; i -= 19 {
-		q, r := u.QuoRem64(1e19) // largest power of 10 that fits in a uint64
-		var n int
-		for ; r != 0; r /= 10 {
-			n++
-			buf[i-n] += byte(r % 10)
-		}
-		if q.IsZero() {
-			return string(buf[i-n:])
-		}
-		u = q
-	}
+	var buf [78]byte // log10(2^256) < 78
+	w := u.Words()
+	return string(nat.AppendDigits(buf[:0], w[:], 10))
 }
 
 // Format does custom formatting of 256-bit value.
+//
+// The output is the same as of (*big.Int).Format for all supported verbs:
+// 'b', 'o', 'O', 'd', 's', 'v', 'x' and 'X', including width, precision
+// and '#', '+', '-', '0', ' ' flags.
 func (u Uint256) Format(s fmt.State, ch rune) {
-	u.Big().Format(s, ch) // via big.Int, unefficient! consider to optimize
+	w := u.Words()
+	nat.Format(s, ch, w[:])
 }
 
 // Scan implements fmt.Scanner.
@@ -60,22 +78,51 @@ func (u *Uint256) Scan(s fmt.ScanState, ch rune) error {
 }
 
 // MarshalText implements the encoding.TextMarshaler interface.
+// The text is the base-10 representation, the same as String().
 func (u Uint256) MarshalText() (text []byte, err error) {
-	return u.Big().MarshalText() // via big.Int, unefficient! consider to optimize
+	return u.AppendText(nil)
+}
+
+// AppendText implements the encoding.TextAppender interface.
+// It appends the same text as MarshalText to dst.
+func (u Uint256) AppendText(dst []byte) ([]byte, error) {
+	return u.AppendDecimal(dst), nil
+}
+
+// AppendDecimal appends the base-10 representation of u to dst.
+func (u Uint256) AppendDecimal(dst []byte) []byte {
+	w := u.Words()
+	return nat.AppendDigits(dst, w[:], 10)
+}
+
+// AppendHex appends the lower case base-16 representation of u to dst,
+// without "0x" prefix. If padded is true, exactly 64 digits are appended.
+func (u Uint256) AppendHex(dst []byte, padded bool) []byte {
+	w := u.Words()
+	if padded {
+		return nat.AppendHexPadded(dst, w[:])
+	}
+	return nat.AppendDigits(dst, w[:], 16)
+}
+
+// AppendBinary implements the encoding.BinaryAppender interface.
+// It appends 32 bytes of u to dst in big-endian byte order.
+func (u Uint256) AppendBinary(dst []byte) ([]byte, error) {
+	w := u.Words()
+	return nat.AppendBigEndian(dst, w[:]), nil
 }
 
 // UnmarshalText implements the encoding.TextUnmarshaler interface.
+// It accepts the same text as ParseUint with base 0, e.g. "123" or "0x7b".
+// Unlike FromString and Scan, which go through big.Int, a sign is not
+// accepted: "+1" is a syntax error. The error is *strconv.NumError. There is no memory allocation unless
+// an error is returned.
 func (u *Uint256) UnmarshalText(text []byte) error {
-	// via big.Int, unefficient! consider to optimize
-	i := new(big.Int)
-	if err := i.UnmarshalText(text); err != nil {
+	var w [4]uint64
+	if err := nat.ParseUint(w[:], text, 0); err != nil {
 		return err
 	}
-	v, ok := FromBigEx(i)
-	if !ok {
-		return fmt.Errorf("%q overflows 256-bit integer", text)
-	}
-	*u = v
+	*u = FromWords(w)
 	return nil
 }
 
@@ -1,8 +1,13 @@
 package uint256
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -106,6 +111,194 @@ func TestUint256Format(t *testing.T) {
 			t.Errorf("Max() should be %q, got %q", expected, got)
 		}
 	})
+	t.Run("rand", func(t *testing.T) {
+		formats := []string{
+			"%b", "%o", "%O", "%d", "%s", "%v", "%x", "%X", "%q",
+			"%#b", "%#o", "%#x", "%#X", "%+d", "% d", "%+#x",
+			"%5d", "%-5d", "%05d", "%100d", "%-100x", "%0100x", "%#0100x",
+			"%.0d", "%.d", "%5.0x", "%.3d", "%.100x", "%0100.50d", "%-#100.50x",
+		}
+
+		values := make(chan Uint256)
+		go generate256s(1000, values)
+		for x := range values {
+			for _, f := range formats {
+				if expected, got := fmt.Sprintf(f, x.Big()), fmt.Sprintf(f, x); got != expected {
+					t.Fatalf("Sprintf(%q) mismatch:\n\t(-) expected %q\n\t(+)   actual %q", f, expected, got)
+				}
+			}
+		}
+	})
+}
+
+// BenchmarkUint256Format performance tests for Uint256.Format() method
+func BenchmarkUint256Format(b *testing.B) {
+	b.ReportAllocs()
+
+	x := rand256()
+	xb := x.Big()
+
+	b.Run("Uint256", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = fmt.Sprintf("%#x", x)
+		}
+	})
+
+	b.Run("big.Int", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = fmt.Sprintf("%#x", xb)
+		}
+	})
+}
+
+// TestParseUint unit tests for ParseUint, FormatUint and AppendUint functions
+func TestParseUint(t *testing.T) {
+	t.Run("manual", func(t *testing.T) {
+		good := map[string]Uint256{
+			"0":            Zero(),
+			"0x_ff":        From64(255),
+			"0o17":         From64(15),
+			"017":          From64(15),
+			"0b1_0":        From64(2),
+			"1_000_000":    From64(1000000),
+			Max().String(): Max(),
+		}
+		for s, expected := range good {
+			if got, err := ParseUint(s, 0); err != nil {
+				t.Errorf("ParseUint(%q) failed: %v", s, err)
+			} else if !got.Equals(expected) {
+				t.Errorf("ParseUint(%q) should be %v, got %v", s, expected, got)
+			}
+		}
+
+		bad := map[string]error{
+			"":                                 strconv.ErrSyntax,
+			"-1":                               strconv.ErrSyntax,
+			"0x":                               strconv.ErrSyntax,
+			"1__0":                             strconv.ErrSyntax,
+			"_1":                               strconv.ErrSyntax,
+			"1_":                               strconv.ErrSyntax,
+			"1 ":                               strconv.ErrSyntax,
+			"0x1" + strings.Repeat("0", 256/4): strconv.ErrRange,
+		}
+		for s, expected := range bad {
+			if _, err := ParseUint(s, 0); !errors.Is(err, expected) {
+				t.Errorf("ParseUint(%q) should fail with %v, got %v", s, expected, err)
+			}
+		}
+		if u, _ := ParseUint("0x1"+strings.Repeat("0", 256/4), 0); !u.Equals(Max()) {
+			t.Errorf("ParseUint should be Max() on overflow, got %v", u)
+		}
+		if _, err := ParseUint("1_0", 10); err == nil {
+			t.Errorf("ParseUint should not allow underscores with explicit base")
+		}
+		if _, err := ParseUint("1", 37); err == nil {
+			t.Errorf("ParseUint should fail on invalid base")
+		}
+	})
+
+	t.Run("rand", func(t *testing.T) {
+		values := make(chan Uint256)
+		go generate256s(1000, values)
+		for x := range values {
+			for base := 2; base <= 36; base++ {
+				s := FormatUint(x, base)
+				if expected := x.Big().Text(base); s != expected {
+					t.Fatalf("FormatUint(%d) mismatch:\n\t(-) expected %q\n\t(+)   actual %q", base, expected, s)
+				}
+				if got := string(AppendUint([]byte("foo"), x, base)); got != "foo"+s {
+					t.Fatalf("AppendUint(%d) mismatch: %q", base, got)
+				}
+				if got, err := ParseUint(s, base); err != nil {
+					t.Fatalf("ParseUint(%q, %d) failed: %v", s, base, err)
+				} else if !got.Equals(x) {
+					t.Fatalf("ParseUint(%q, %d) mismatch: actual %v", s, base, got)
+				}
+			}
+		}
+	})
+
+	t.Run("allocs", func(t *testing.T) {
+		s := Max().String()
+		if n := testing.AllocsPerRun(100, func() { _, _ = ParseUint(s, 0) }); n != 0 {
+			t.Errorf("ParseUint should not allocate, got %v allocs", n)
+		}
+
+		var u Uint256
+		text := []byte(Max().String())
+		if n := testing.AllocsPerRun(100, func() { _ = u.UnmarshalText(text) }); n != 0 || u != Max() {
+			t.Errorf("UnmarshalText should not allocate, got %v allocs", n)
+		}
+		if err := u.UnmarshalText([]byte("+1")); !errors.Is(err, strconv.ErrSyntax) {
+			t.Errorf("UnmarshalText(\"+1\") should fail with %v, got %v", strconv.ErrSyntax, err)
+		}
+	})
+}
+
+// BenchmarkParseUint performance tests for ParseUint function
+func BenchmarkParseUint(b *testing.B) {
+	b.ReportAllocs()
+
+	s := rand256().String()
+
+	b.Run("Uint256", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = ParseUint(s, 10)
+		}
+	})
+
+	b.Run("big.Int", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = new(big.Int).SetString(s, 10)
+		}
+	})
+}
+
+// TestAppend unit tests for Append* methods
+func TestAppend(t *testing.T) {
+	// the standard interfaces (Go 1.24)
+	var _ interface{ AppendText([]byte) ([]byte, error) } = Zero()
+	var _ interface{ AppendBinary([]byte) ([]byte, error) } = Zero()
+
+	t.Run("rand", func(t *testing.T) {
+		values := make(chan Uint256)
+		go generate256s(1000, values)
+		for x := range values {
+			prefix := []byte("foo")
+			if expected, got := "foo"+x.Big().String(), string(x.AppendDecimal(prefix)); got != expected {
+				t.Fatalf("AppendDecimal mismatch:\n\t(-) expected %q\n\t(+)   actual %q", expected, got)
+			}
+			if text, err := x.AppendText(prefix); err != nil || string(text) != "foo"+x.String() {
+				t.Fatalf("AppendText mismatch: %q, %v", text, err)
+			}
+			if text, err := x.MarshalText(); err != nil || string(text) != x.String() {
+				t.Fatalf("MarshalText mismatch: %q, %v", text, err)
+			}
+			if expected, got := fmt.Sprintf("foo%x", x.Big()), string(x.AppendHex(prefix, false)); got != expected {
+				t.Fatalf("AppendHex mismatch:\n\t(-) expected %q\n\t(+)   actual %q", expected, got)
+			}
+			if expected, got := fmt.Sprintf("foo%064x", x.Big()), string(x.AppendHex(prefix, true)); got != expected {
+				t.Fatalf("AppendHex(padded) mismatch:\n\t(-) expected %q\n\t(+)   actual %q", expected, got)
+			}
+			expected := append(prefix, x.Big().FillBytes(make([]byte, 32))...)
+			if got, err := x.AppendBinary(prefix); err != nil || !bytes.Equal(got, expected) {
+				t.Fatalf("AppendBinary mismatch:\n\t(-) expected %x\n\t(+)   actual %x", expected, got)
+			}
+		}
+	})
+
+	t.Run("allocs", func(t *testing.T) {
+		x := Max()
+		buf := make([]byte, 0, 1024)
+		if n := testing.AllocsPerRun(100, func() {
+			buf, _ = x.AppendText(buf[:0])
+			buf = x.AppendHex(buf[:0], true)
+			buf = x.AppendHex(buf[:0], false)
+			buf, _ = x.AppendBinary(buf[:0])
+		}); n != 0 {
+			t.Errorf("Append* should not allocate, got %v allocs", n)
+		}
+	})
 }
 
 // TestStoreLoad unit tests for bytes load/store functions
@@ -179,3 +372,75 @@ func TestJSON(t *testing.T) {
 		}
 	})
 }
+
+// TestJSONFormats unit tests for JSON encodings
+func TestJSONFormats(t *testing.T) {
+	type Foo struct {
+		Str Uint256    `json:"str"`
+		Num JSONNumber `json:"num"`
+		Hex JSONHex    `json:"hex"`
+	}
+
+	t.Run("manual", func(t *testing.T) {
+		buf, err := json.Marshal(Foo{Str: From64(123), Num: JSONNumber(From64(123)), Hex: JSONHex(From64(123))})
+		if err != nil {
+			t.Fatalf("failed to marshal to JSON: %v", err)
+		}
+		if expected, got := `{"str":"123","num":123,"hex":"0x7b"}`, string(buf); got != expected {
+			t.Fatalf("JSON mismatch:\n\t(-) expected %s\n\t(+)   actual %s", expected, got)
+		}
+
+		// all encodings are accepted by all types
+		for _, data := range []string{
+			`{"str":123,"num":123,"hex":123}`,
+			`{"str":"123","num":"123","hex":"123"}`,
+			`{"str":"0x7b","num":"0x7B","hex":"0X7b"}`,
+		} {
+			var tmp Foo
+			if err := json.Unmarshal([]byte(data), &tmp); err != nil {
+				t.Fatalf("failed to unmarshal %s: %v", data, err)
+			}
+			if tmp.Str != From64(123) || Uint256(tmp.Num) != From64(123) || Uint256(tmp.Hex) != From64(123) {
+				t.Fatalf("unmarshal %s mismatch: %+v", data, tmp)
+			}
+		}
+
+		// null is no-op
+		tmp := Foo{Str: One()}
+		if err := json.Unmarshal([]byte(`{"str":null}`), &tmp); err != nil || tmp.Str != One() {
+			t.Fatalf("null should be no-op: %v, %v", tmp.Str, err)
+		}
+	})
+
+	t.Run("bad", func(t *testing.T) {
+		for _, data := range []string{
+			`-1`, `1.0`, `1e3`, `"-1"`, `""`, `"0x"`, `"0x-1"`, `"0b1"`, `"1_000"`, `" 1"`, `true`, `[]`,
+			`"0x1` + strings.Repeat("0", 256/4) + `"`,
+			Max().String() + "0",
+		} {
+			var tmp Uint256
+			if err := tmp.UnmarshalJSON([]byte(data)); err == nil {
+				t.Errorf("UnmarshalJSON(%s) should fail", data)
+			}
+		}
+	})
+
+	t.Run("rand", func(t *testing.T) {
+		values := make(chan Uint256)
+		go generate256s(1000, values)
+		for x := range values {
+			buf, err := json.Marshal(Foo{Str: x, Num: JSONNumber(x), Hex: JSONHex(x)})
+			if err != nil {
+				t.Fatalf("failed to marshal to JSON: %v", err)
+			}
+
+			var tmp Foo
+			if err := json.Unmarshal(buf, &tmp); err != nil {
+				t.Fatalf("failed to unmarshal %s: %v", buf, err)
+			}
+			if tmp.Str != x || Uint256(tmp.Num) != x || Uint256(tmp.Hex) != x {
+				t.Fatalf("%s does not equal itself after JSON decoding, got: %+v", buf, tmp)
+			}
+		}
+	})
+}
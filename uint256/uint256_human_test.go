@@ -0,0 +1,82 @@
+package uint256
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+// TestHuman unit tests for human-readable formatting and parsing
+func TestHuman(t *testing.T) {
+	t.Run("manual", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			got      string
+			expected string
+		}{
+			{"FormatGrouped", FormatGrouped(Zero(), ",", 3), "0"},
+			{"FormatGrouped", FormatGrouped(From64(1234567), ",", 3), "1,234,567"},
+			{"FormatGrouped", FormatGrouped(From64(1234567), "_", 4), "123_4567"},
+			{"FormatGrouped", FormatGrouped(Max(), ",", 3), "115,792,089,237,316,195,423,570,985,008,687,907,853,269,984,665,640,564,039,457,584,007,913,129,639,935"},
+			{"FormatSI", FormatSI(From64(999), 2), "999.00"},
+			{"FormatSI", FormatSI(From64(1500000), 1), "1.5M"},
+			{"FormatSI", FormatSI(From64(1234567), -1), "1.234567M"},
+			{"FormatSI", FormatSI(From64(999995), 2), "1.00M"},
+			{"FormatSI", FormatSI(Max(), 2), "115792089237316195423570985008687907853269984665.64Q"},
+			{"FormatIEC", FormatIEC(From64(1536), 2), "1.50Ki"},
+			{"FormatIEC", FormatIEC(From64(1<<30), -1), "1Gi"},
+			{"FormatIEC", FormatIEC(From64(1<<20-1), 2), "1.00Mi"},
+			{"FormatIEC", FormatIEC(Max(), 2), "95780971304118053647396689196894323976171195136475136.00Yi"},
+			{"FormatSci", FormatSci(Zero(), 2), "0.00e+00"},
+			{"FormatSci", FormatSci(From64(12345), -1), "1.2345e+04"},
+			{"FormatSci", FormatSci(Max(), 4), "1.1579e+77"},
+		}
+		for _, tt := range tests {
+			if tt.got != tt.expected {
+				t.Errorf("%s mismatch:\n\t(-) expected %s\n\t(+)   actual %s", tt.name, tt.expected, tt.got)
+			}
+		}
+
+		parse := []struct {
+			s        string
+			expected Uint256
+		}{
+			{"0", Zero()},
+			{"1.5M", From64(1500000)},
+			{"3e18", From64(3000000000000000000)},
+			{"1.5Ki", From64(1536)},
+			{"2.5e3k", From64(2500000)},
+		}
+		for _, tt := range parse {
+			if got, err := ParseHuman(tt.s); err != nil || got != tt.expected {
+				t.Errorf("ParseHuman(%s) mismatch: %v, %v", tt.s, got, err)
+			}
+		}
+		for _, s := range []string{"", "-1", "1.5x", ".5", "1.2345k"} {
+			if got, err := ParseHuman(s); err == nil {
+				t.Errorf("ParseHuman(%q) should fail, got %v", s, got)
+			}
+		}
+		if _, err := ParseHuman(FormatSI(Max(), -1) + "Q"); !errors.Is(err, strconv.ErrSyntax) {
+			t.Errorf("ParseHuman should fail with syntax error, got %v", err)
+		}
+		if got, err := ParseHuman(Max().String() + "0e-1"); err != nil || got != Max() {
+			t.Errorf("ParseHuman(Max) mismatch: %v, %v", got, err)
+		}
+		for _, s := range []string{Max().String() + "k", FormatSci(Max(), -1) + "0"} {
+			if _, err := ParseHuman(s); !errors.Is(err, strconv.ErrRange) {
+				t.Errorf("ParseHuman(%s) should fail with range error, got %v", s, err)
+			}
+		}
+	})
+
+	t.Run("rand", func(t *testing.T) {
+		for _, x := range rand256slice(1000) {
+			for _, s := range []string{FormatSI(x, -1), FormatIEC(x, -1), FormatSci(x, -1), x.String()} {
+				if got, err := ParseHuman(s); err != nil || got != x {
+					t.Fatalf("%s does not equal itself after parsing, got: %v, %v", s, got, err)
+				}
+			}
+		}
+	})
+}
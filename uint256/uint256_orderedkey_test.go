@@ -0,0 +1,66 @@
+package uint256
+
+import (
+	"bytes"
+	"encoding/hex"
+	"sort"
+	"testing"
+)
+
+// TestOrderedKey unit tests for order-preserving keys
+func TestOrderedKey(t *testing.T) {
+	t.Run("manual", func(t *testing.T) {
+		tests := []struct {
+			x   Uint256
+			key string
+		}{
+			{Zero(), "00"},
+			{One(), "0101"},
+			{From64(0x1234), "021234"},
+			{Max(), "20ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"},
+		}
+		for _, tt := range tests {
+			if got := hex.EncodeToString(tt.x.AppendOrderedKey(nil)); got != tt.key {
+				t.Errorf("AppendOrderedKey(%v) mismatch:\n\t(-) expected %s\n\t(+)   actual %s", tt.x, tt.key, got)
+			}
+			desc := tt.x.AppendOrderedKeyDesc(nil)
+			if got, rest, err := DecodeOrderedKeyDesc(append(desc, 0x05)); err != nil || got != tt.x || !bytes.Equal(rest, []byte{0x05}) {
+				t.Errorf("DecodeOrderedKeyDesc(%x) mismatch: %v, %v", desc, got, err)
+			}
+		}
+
+		for _, key := range []string{"", "02ff", "0100", "21010000000000000000000000000000000000000000000000000000000000000000"} {
+			b, _ := hex.DecodeString(key)
+			if got, _, err := DecodeOrderedKey(b); err == nil {
+				t.Errorf("DecodeOrderedKey(%s) should fail, got %v", key, got)
+			}
+		}
+	})
+
+	t.Run("rand", func(t *testing.T) {
+		values := rand256slice(1000)
+		for i := range values {
+			values[i] = values[i].Rsh(uint(i % 256)) // various lengths
+		}
+		asc := make([][]byte, len(values))
+		desc := make([][]byte, len(values))
+		for i, x := range values {
+			asc[i], desc[i] = x.AppendOrderedKey(nil), x.AppendOrderedKeyDesc(nil)
+			if got, rest, err := DecodeOrderedKey(asc[i]); err != nil || got != x || len(rest) != 0 {
+				t.Fatalf("%x does not equal itself after decoding, got: %v, %v", asc[i], got, err)
+			}
+		}
+
+		sort.Slice(values, func(i, j int) bool { return values[i].Cmp(values[j]) < 0 })
+		sort.Slice(asc, func(i, j int) bool { return bytes.Compare(asc[i], asc[j]) < 0 })
+		sort.Slice(desc, func(i, j int) bool { return bytes.Compare(desc[i], desc[j]) > 0 })
+		for i, x := range values {
+			if a, _, err := DecodeOrderedKey(asc[i]); err != nil || a != x {
+				t.Fatalf("ascending key #%d mismatch: expected %v, got %v", i, x, a)
+			}
+			if d, _, err := DecodeOrderedKeyDesc(desc[i]); err != nil || d != x {
+				t.Fatalf("descending key #%d mismatch: expected %v, got %v", i, x, d)
+			}
+		}
+	})
+}
@@ -0,0 +1,142 @@
+package uint256
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/piliming/bigz/internal/nat"
+)
+
+// Quantity decoding errors, the same messages as of go-ethereum's hexutil package.
+var (
+	errQuantityEmpty     = errors.New("empty hex string")
+	errQuantityNoPrefix  = errors.New("hex string without 0x prefix")
+	errQuantityNoDigits  = errors.New("hex string \"0x\"")
+	errQuantityLeading0  = errors.New("hex number with leading zero digits")
+	errQuantitySyntax    = errors.New("invalid hex string")
+	errQuantityRange     = errors.New("hex number > 256 bits")
+	errQuantityNonString = errors.New("cannot unmarshal non-string into Quantity")
+)
+
+// Quantity is a Uint256 encoded as Ethereum JSON-RPC quantity: a quoted
+// hex string with "0x" prefix and without leading zeros, e.g. "0x7b".
+// Zero is "0x0". Unlike JSONHex, decoding is strict: "0x", "0x00",
+// missing prefix, decimal strings and bare JSON numbers are rejected.
+// It is a drop-in replacement for go-ethereum's hexutil.Big.
+type Quantity Uint256
+
+// ParseQuantity parses the JSON-RPC quantity string, e.g. "0x7b".
+func ParseQuantity(s string) (Quantity, error) {
+	u, err := parseQuantity(s)
+	return Quantity(u), err
+}
+
+// parseQuantity strictly parses the JSON-RPC quantity string.
+func parseQuantity[S ~string | ~[]byte](s S) (Uint256, error) {
+	switch {
+	case len(s) == 0:
+		return Zero(), errQuantityEmpty
+	case len(s) < 2 || s[0] != '0' || (s[1] != 'x' && s[1] != 'X'):
+		return Zero(), errQuantityNoPrefix
+	case len(s) == 2:
+		return Zero(), errQuantityNoDigits
+	case len(s) > 3 && s[2] == '0':
+		return Zero(), errQuantityLeading0
+	case len(s)-2 > 256/4:
+		return Zero(), errQuantityRange
+	}
+
+	var w [4]uint64
+	if err := nat.ParseUint(w[:], s[2:], 16); err != nil {
+		return Zero(), errQuantitySyntax
+	}
+	return FromWords(w), nil
+}
+
+// String returns the quantity string, e.g. "0x7b".
+func (q Quantity) String() string {
+	return string(q.appendText(make([]byte, 0, 2+64)))
+}
+
+// AppendText implements the encoding.TextAppender interface.
+// It appends the quantity string to dst.
+func (q Quantity) AppendText(dst []byte) ([]byte, error) {
+	return q.appendText(dst), nil
+}
+
+// appendText appends the quantity string to dst.
+func (q Quantity) appendText(dst []byte) []byte {
+	dst = append(dst, '0', 'x')
+	return Uint256(q).AppendHex(dst, false)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (q Quantity) MarshalText() ([]byte, error) {
+	return q.appendText(make([]byte, 0, 2+64)), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (q *Quantity) UnmarshalText(text []byte) error {
+	u, err := parseQuantity(text)
+	if err != nil {
+		return fmt.Errorf("%q is not a valid quantity: %w", text, err)
+	}
+	*q = Quantity(u)
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (q Quantity) MarshalJSON() ([]byte, error) {
+	buf := make([]byte, 0, 2+2+64)
+	buf = append(buf, '"')
+	buf = q.appendText(buf)
+	return append(buf, '"'), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Only a quoted quantity string is accepted, the JSON null value is a no-op.
+func (q *Quantity) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return fmt.Errorf("%s is not a valid quantity: %w", data, errQuantityNonString)
+	}
+	return q.UnmarshalText(data[1 : len(data)-1])
+}
+
+// NewQuantity returns a pointer to the Quantity of u,
+// which is handy for optional struct fields.
+func NewQuantity(u Uint256) *Quantity {
+	q := Quantity(u)
+	return &q
+}
+
+// ToUint256 returns the Uint256 pointer of the same value, nil for nil q.
+func (q *Quantity) ToUint256() *Uint256 {
+	return (*Uint256)(q)
+}
+
+// ToQuantities converts values to a slice of Quantity.
+func ToQuantities(values []Uint256) []Quantity {
+	if values == nil {
+		return nil
+	}
+	out := make([]Quantity, len(values))
+	for i, u := range values {
+		out[i] = Quantity(u)
+	}
+	return out
+}
+
+// FromQuantities converts a slice of Quantity to values.
+func FromQuantities(values []Quantity) []Uint256 {
+	if values == nil {
+		return nil
+	}
+	out := make([]Uint256, len(values))
+	for i, q := range values {
+		out[i] = Uint256(q)
+	}
+	return out
+}
@@ -0,0 +1,124 @@
+package uint256
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestQuantity unit tests for JSON-RPC quantity encoding
+func TestQuantity(t *testing.T) {
+	// the standard interface (Go 1.24)
+	var _ interface{ AppendText([]byte) ([]byte, error) } = Quantity{}
+
+	t.Run("manual", func(t *testing.T) {
+		tests := map[Uint256]string{
+			Zero():            "0x0",
+			One():             "0x1",
+			From64(0x7b):      "0x7b",
+			From64(0x400):     "0x400",
+			One().Lsh(64):     "0x10000000000000000",
+			Max():             "0x" + strings.Repeat("f", 64),
+			From64(0xabcdef0): "0xabcdef0",
+		}
+		for x, expected := range tests {
+			if got := Quantity(x).String(); got != expected {
+				t.Errorf("Quantity(%v).String() mismatch:\n\t(-) expected %s\n\t(+)   actual %s", x, expected, got)
+			}
+			if got, err := ParseQuantity(expected); err != nil || Uint256(got) != x {
+				t.Errorf("ParseQuantity(%s) mismatch: %v, %v", expected, got, err)
+			}
+			if got, err := Quantity(x).AppendText([]byte("foo")); err != nil || string(got) != "foo"+expected {
+				t.Errorf("Quantity(%v).AppendText mismatch: %s, %v", x, got, err)
+			}
+		}
+
+		// upper case digits and prefix are accepted as by hexutil
+		if got, err := ParseQuantity("0XAB"); err != nil || Uint256(got) != From64(0xab) {
+			t.Errorf("ParseQuantity(0XAB) mismatch: %v, %v", got, err)
+		}
+	})
+
+	t.Run("bad", func(t *testing.T) {
+		tests := map[string]error{
+			"":                              errQuantityEmpty,
+			"0":                             errQuantityNoPrefix,
+			"123":                           errQuantityNoPrefix,
+			"x1":                            errQuantityNoPrefix,
+			"0x":                            errQuantityNoDigits,
+			"0x00":                          errQuantityLeading0,
+			"0x01":                          errQuantityLeading0,
+			"0xg":                           errQuantitySyntax,
+			"0x+1":                          errQuantitySyntax,
+			"0x-1":                          errQuantitySyntax,
+			"0x_1":                          errQuantitySyntax,
+			"0x1 ":                          errQuantitySyntax,
+			"0x1" + strings.Repeat("0", 64): errQuantityRange,
+		}
+		for s, expected := range tests {
+			if _, err := ParseQuantity(s); err != expected {
+				t.Errorf("ParseQuantity(%q) should fail with %v, got %v", s, expected, err)
+			}
+		}
+
+		for _, data := range []string{`0`, `123`, `"123"`, `"0x"`, `"0x00"`, `true`, `[]`, `"0x1`} {
+			var q Quantity
+			if err := q.UnmarshalJSON([]byte(data)); err == nil {
+				t.Errorf("UnmarshalJSON(%s) should fail", data)
+			}
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		type Foo struct {
+			Value  Quantity   `json:"value"`
+			Opt    *Quantity  `json:"opt,omitempty"`
+			Values []Quantity `json:"values"`
+		}
+		foo := Foo{
+			Value:  Quantity(From64(0x7b)),
+			Opt:    NewQuantity(Zero()),
+			Values: ToQuantities([]Uint256{One(), Max()}),
+		}
+		buf, err := json.Marshal(foo)
+		if err != nil {
+			t.Fatalf("failed to marshal to JSON: %v", err)
+		}
+		expected := `{"value":"0x7b","opt":"0x0","values":["0x1","0x` + strings.Repeat("f", 64) + `"]}`
+		if got := string(buf); got != expected {
+			t.Fatalf("JSON mismatch:\n\t(-) expected %s\n\t(+)   actual %s", expected, got)
+		}
+
+		var tmp Foo
+		if err := json.Unmarshal(buf, &tmp); err != nil {
+			t.Fatalf("failed to unmarshal %s: %v", buf, err)
+		}
+		if tmp.Value != foo.Value || !tmp.Opt.ToUint256().IsZero() {
+			t.Fatalf("unmarshal %s mismatch: %+v", buf, tmp)
+		}
+		if values := FromQuantities(tmp.Values); len(values) != 2 || values[0] != One() || values[1] != Max() {
+			t.Fatalf("unmarshal %s mismatch: %v", buf, values)
+		}
+
+		// null is no-op, missing optional field is nil
+		tmp = Foo{Value: Quantity(One())}
+		if err := json.Unmarshal([]byte(`{"value":null}`), &tmp); err != nil || Uint256(tmp.Value) != One() || tmp.Opt.ToUint256() != nil {
+			t.Fatalf("null should be no-op: %+v, %v", tmp, err)
+		}
+		if ToQuantities(nil) != nil || FromQuantities(nil) != nil {
+			t.Fatalf("nil slices should stay nil")
+		}
+	})
+
+	t.Run("rand", func(t *testing.T) {
+		values := make(chan Uint256)
+		go generate256s(1000, values)
+		for x := range values {
+			text, _ := Quantity(x).MarshalText()
+			var q Quantity
+			if err := q.UnmarshalText(text); err != nil || Uint256(q) != x {
+				t.Fatalf("%s does not equal itself after decoding, got: %v, %v", text, q, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package uint256
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+)
+
+var (
+	_ driver.Valuer = Uint256{}
+	_ driver.Valuer = Numeric{}
+	_ sql.Scanner   = (*Numeric)(nil)
+	_ driver.Valuer = FixedBytes{}
+	_ sql.Scanner   = (*FixedBytes)(nil)
+)
+
+// TestSQL unit tests for database/sql support
+func TestSQL(t *testing.T) {
+	t.Run("manual", func(t *testing.T) {
+		if v, err := Max().Value(); err != nil || v != Max().String() {
+			t.Errorf("Value() mismatch: %v, %v", v, err)
+		}
+
+		var u Numeric
+		for _, src := range []interface{}{int64(123), uint64(123), "123", []byte("123"), "123.00"} {
+			if err := u.Scan(src); err != nil || Uint256(u) != From64(123) {
+				t.Errorf("Scan(%#v) mismatch: %v, %v", src, Uint256(u), err)
+			}
+		}
+		for _, src := range []interface{}{nil, int64(-1), "-1", "1.5", "abc", 1.0, Max().String() + "0"} {
+			if err := u.Scan(src); err == nil {
+				t.Errorf("Scan(%#v) should fail", src)
+			}
+		}
+
+		var f FixedBytes
+		for _, src := range []interface{}{nil, "123", int64(1), make([]byte, 256/8-1), make([]byte, 256/8+1)} {
+			if err := f.Scan(src); err == nil {
+				t.Errorf("FixedBytes.Scan(%#v) should fail", src)
+			}
+		}
+	})
+
+	t.Run("rand", func(t *testing.T) {
+		for _, x := range rand256slice(1000) {
+			v, err := Numeric(x).Value()
+			if err != nil {
+				t.Fatalf("Value() failed: %v", err)
+			}
+			var u Numeric
+			if err := u.Scan(v); err != nil || Uint256(u) != x {
+				t.Fatalf("%v does not equal itself after scanning, got: %v, %v", v, Uint256(u), err)
+			}
+
+			v, err = FixedBytes(x).Value()
+			if b, ok := v.([]byte); err != nil || !ok || !bytes.Equal(b, x.Big().FillBytes(make([]byte, 256/8))) {
+				t.Fatalf("FixedBytes(%v).Value() mismatch: %x, %v", x, v, err)
+			}
+			var f FixedBytes
+			if err := f.Scan(v); err != nil || Uint256(f) != x {
+				t.Fatalf("%x does not equal itself after scanning, got: %v, %v", v, Uint256(f), err)
+			}
+		}
+	})
+}
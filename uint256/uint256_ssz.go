@@ -0,0 +1,41 @@
+package uint256
+
+import "fmt"
+
+// SSZ (SimpleSerialize) encoding of uint256 basic type: 32 bytes in
+// little-endian byte order. The methods match the interfaces used by
+// fastssz generated code (ssz.Marshaler, ssz.Unmarshaler, ssz.HashRoot).
+
+// SizeSSZ returns the size of SSZ encoding, always 32 bytes.
+func (u Uint256) SizeSSZ() int {
+	return 32
+}
+
+// MarshalSSZ returns the SSZ encoding of u.
+func (u Uint256) MarshalSSZ() ([]byte, error) {
+	return u.MarshalSSZTo(make([]byte, 0, 32))
+}
+
+// MarshalSSZTo appends the SSZ encoding of u to dst.
+func (u Uint256) MarshalSSZTo(dst []byte) ([]byte, error) {
+	dst = append(dst, make([]byte, 32)...)
+	StoreLittleEndian(dst[len(dst)-32:], u)
+	return dst, nil
+}
+
+// UnmarshalSSZ decodes u from SSZ encoding, which must be exactly 32 bytes.
+func (u *Uint256) UnmarshalSSZ(buf []byte) error {
+	if len(buf) != 32 {
+		return fmt.Errorf("invalid uint256 SSZ size %d, expected 32", len(buf))
+	}
+	*u = LoadLittleEndian(buf)
+	return nil
+}
+
+// HashTreeRoot returns the SSZ hash tree root of u. For a basic type it is
+// the single 32-byte chunk: the little-endian encoding padded with zeros.
+func (u Uint256) HashTreeRoot() ([32]byte, error) {
+	var root [32]byte
+	StoreLittleEndian(root[:32], u)
+	return root, nil
+}
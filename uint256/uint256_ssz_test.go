@@ -0,0 +1,72 @@
+package uint256
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/piliming/bigz/uint128"
+)
+
+// TestSSZ unit tests for SSZ encoding
+func TestSSZ(t *testing.T) {
+	t.Run("manual", func(t *testing.T) {
+		// 0x0102 is 02 01 00 ... in little-endian
+		expected := make([]byte, 32)
+		expected[0], expected[1] = 0x02, 0x01
+		if got, err := From64(0x0102).MarshalSSZ(); err != nil || !bytes.Equal(got, expected) {
+			t.Errorf("MarshalSSZ mismatch:\n\t(-) expected %x\n\t(+)   actual %x", expected, got)
+		}
+
+		// a 256-bit value is a single chunk, its root is the chunk itself
+		roots := []struct {
+			x        Uint256
+			expected string
+		}{
+			{Zero(), strings.Repeat("00", 32)},
+			{From64(0x0102), "0201" + strings.Repeat("00", 30)},
+			{Max(), strings.Repeat("ff", 32)},
+			{Uint256{Hi: uint128.Uint128{Hi: 1 << 63}}, strings.Repeat("00", 31) + "80"},
+		}
+		for _, tt := range roots {
+			if root, err := tt.x.HashTreeRoot(); err != nil || hex.EncodeToString(root[:]) != tt.expected {
+				t.Errorf("HashTreeRoot(%#x) mismatch:\n\t(-) expected %s\n\t(+)   actual %x", tt.x, tt.expected, root)
+			}
+		}
+
+		var u Uint256
+		for _, n := range []int{0, 32 - 1, 32 + 1} {
+			if err := u.UnmarshalSSZ(make([]byte, n)); err == nil {
+				t.Errorf("UnmarshalSSZ(%d bytes) should fail", n)
+			}
+		}
+	})
+
+	t.Run("rand", func(t *testing.T) {
+		values := make(chan Uint256)
+		go generate256s(1000, values)
+		for x := range values {
+			if x.SizeSSZ() != 32 {
+				t.Fatalf("SizeSSZ should be 32, got %d", x.SizeSSZ())
+			}
+
+			buf, err := x.MarshalSSZTo([]byte("foo"))
+			if err != nil || len(buf) != 3+32 || string(buf[:3]) != "foo" {
+				t.Fatalf("MarshalSSZTo(%#x) mismatch: %x, %v", x, buf, err)
+			}
+			var u Uint256
+			if err := u.UnmarshalSSZ(buf[3:]); err != nil || !u.Equals(x) {
+				t.Fatalf("UnmarshalSSZ is not the inverse of MarshalSSZTo for %#x, got %#x, %v", x, u, err)
+			}
+			if expected := x.ReverseBytes().FillBytes(make([]byte, 32)); !bytes.Equal(buf[3:], expected) {
+				t.Fatalf("MarshalSSZTo(%#x) should be little-endian: %x", x, buf[3:])
+			}
+
+			root, err := x.HashTreeRoot()
+			if err != nil || !bytes.Equal(root[:], buf[3:]) {
+				t.Fatalf("HashTreeRoot(%#x) mismatch: %x", x, root)
+			}
+		}
+	})
+}
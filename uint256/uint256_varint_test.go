@@ -0,0 +1,65 @@
+package uint256
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+)
+
+// TestUvarint unit tests for LEB128 encoding
+func TestUvarint(t *testing.T) {
+	t.Run("manual", func(t *testing.T) {
+		for _, v := range []uint64{0, 1, 0x7f, 0x80, 300, 1<<63 - 1, 1<<64 - 1} {
+			if got, expected := From64(v).AppendUvarint(nil), binary.AppendUvarint(nil, v); !bytes.Equal(got, expected) {
+				t.Errorf("AppendUvarint(%d) mismatch:\n\t(-) expected %x\n\t(+)   actual %x", v, expected, got)
+			}
+		}
+		if got := len(Max().AppendUvarint(nil)); got != MaxVarintLen {
+			t.Errorf("Max() encoding length should be %d, got %d", MaxVarintLen, got)
+		}
+
+		for _, b := range [][]byte{
+			{},           // empty
+			{0x80},       // truncated
+			{0x80, 0x00}, // trailing zero
+			append(Max().AppendUvarint(nil)[:MaxVarintLen-1], 0xff, 0x01), // overflow
+			append(bytes.Repeat([]byte{0xff}, MaxVarintLen-1), 0x7f),      // overflow
+		} {
+			if _, _, err := DecodeUvarint(b); err == nil {
+				t.Errorf("DecodeUvarint(%x) should fail", b)
+			}
+			if _, err := ReadUvarint(bytes.NewReader(b)); err == nil {
+				t.Errorf("ReadUvarint(%x) should fail", b)
+			}
+		}
+		if _, _, err := DecodeUvarint([]byte{0x80}); err != io.ErrUnexpectedEOF {
+			t.Errorf("DecodeUvarint(80) should fail with unexpected EOF, got %v", err)
+		}
+	})
+
+	t.Run("rand", func(t *testing.T) {
+		var stream bytes.Buffer
+		var values []Uint256
+		for _, x := range rand256slice(1000) {
+			x = x.Rsh(uint(x.Lo.Lo % 256)) // more short values
+			enc := x.AppendUvarint(nil)
+			if got, rest, err := DecodeUvarint(append(enc, 0x05)); err != nil || !bytes.Equal(rest, []byte{0x05}) || !got.Equals(x) {
+				t.Fatalf("%x does not equal itself after decoding, got: %v, rest %x, %v", enc, got, rest, err)
+			}
+			stream.Write(enc)
+			values = append(values, x)
+		}
+
+		r := bufio.NewReader(&stream)
+		for _, x := range values {
+			if got, err := ReadUvarint(r); err != nil || !got.Equals(x) {
+				t.Fatalf("ReadUvarint mismatch: %v, %v, expected %v", got, err, x)
+			}
+		}
+		if _, err := ReadUvarint(r); err != io.EOF {
+			t.Fatalf("ReadUvarint at the end should fail with EOF, got %v", err)
+		}
+	})
+}
@@ -1,6 +1,8 @@
 package bigz
 
 import (
+	"math/big"
+
 	u256 "github.com/piliming/bigz/uint256"
 )
 
@@ -25,3 +27,25 @@ func One256() Uint256 {
 func Max256() Uint256 {
 	return u256.Max()
 }
+
+// From64To256 converts 64-bit value v to a Uint256 value.
+func From64To256(v uint64) Uint256 {
+	return u256.From64(v)
+}
+
+// FromBig256 converts *big.Int to Uint256 value with saturation.
+// See FromBigEx256 to detect overflows.
+func FromBig256(i *big.Int) Uint256 {
+	return u256.FromBig(i)
+}
+
+// FromBigEx256 converts *big.Int to Uint256 value (eXtended version).
+// Provides ok successful flag as a second return value.
+func FromBigEx256(i *big.Int) (Uint256, bool) {
+	return u256.FromBigEx(i)
+}
+
+// Parse256 parses input string as a Uint256 value.
+func Parse256(s string) (Uint256, error) {
+	return u256.FromString(s)
+}
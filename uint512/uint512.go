@@ -8,7 +8,6 @@ import (
 	"math/big"
 	"math/bits"
 	"slices"
-	"unsafe"
 )
 
 const bitCount = 512
@@ -37,37 +36,46 @@ func Max() Uint512 {
 	}
 }
 
+// Uint128 is an unsigned 128-bit number alias.
 type Uint128 = uint128.Uint128
+
+// Uint256 is an unsigned 256-bit number alias.
 type Uint256 = uint256.Uint256
 
+// Uint512 is an unsigned 512-bit number.
+// All methods are immutable, works just like standard uint64.
 type Uint512 struct {
-	Lo Uint256
-	Hi Uint256
+	Lo Uint256 // lower 256-bit half
+	Hi Uint256 // upper 256-bit half
 }
 
+// From256 converts 256-bit value v to a Uint512 value.
+// Upper bits will be zero.
 func From256(v Uint256) Uint512 {
 	return Uint512{Lo: v}
 }
 
+// From128 converts 128-bit value v to a Uint512 value.
+// Upper bits will be zero.
 func From128(v Uint128) Uint512 {
 	return Uint512{Lo: uint256.From128(v)}
 }
 
-// From64 converts 64-bit value v to a Uint256 value.
-// Upper 128-bit half will be zero.
+// From64 converts 64-bit value v to a Uint512 value.
+// Upper bits will be zero.
 func From64(v uint64) Uint512 {
 	return From128(uint128.From64(v))
 }
 
-// FromBig converts *big.Int to 256-bit Uint256 value ignoring overflows.
+// FromBig converts *big.Int to 512-bit Uint512 value ignoring overflows.
 // If input integer is nil or negative then return Zero.
-// If input interger overflows 256-bit then return Max.
+// If input interger overflows 512-bit then return Max.
 func FromBig(i *big.Int) Uint512 {
 	u, _ := FromBigEx(i)
 	return u
 }
 
-// FromBigEx converts *big.Int to 256-bit Uint256 value (eXtended version).
+// FromBigEx converts *big.Int to 512-bit Uint512 value (eXtended version).
 // Provides ok successful flag as a second return value.
 // If input integer is negative or overflows 512-bit then ok=false.
 // If input is nil then zero 512-bit returned.
@@ -81,46 +89,88 @@ func FromBigEx(i *big.Int) (Uint512, bool) {
 		return Max(), false // value overflows 512-bit!
 	}
 
-	buf := i.Bytes()
-	slices.Reverse(buf)
-
-	arr := [byteCount]byte{}
-
-	copy(arr[:], buf)
-
-	return LoadLittleEndian(arr), true
+	var buf [byteCount]byte
+	return LoadBigEndian(i.FillBytes(buf[:])), true
 }
 
+// Big returns 512-bit value as a *big.Int.
 func (u Uint512) Big() *big.Int {
-
-	buf := [byteCount]byte{}
-
-	copy(buf[:], (*(*[byteCount]byte)(unsafe.Pointer(&u)))[:])
-
+	buf := u.littleEndianBytes()
 	slices.Reverse(buf[:])
 
 	return new(big.Int).SetBytes(buf[:])
 }
 
+// Words returns 512-bit value as an array of 64-bit limbs
+// in little-endian order, i.e. Words()[0] is the least significant limb.
+func (u Uint512) Words() [8]uint64 {
+	return [uint64Count]uint64{
+		u.Lo.Lo.Lo,
+		u.Lo.Lo.Hi,
+		u.Lo.Hi.Lo,
+		u.Lo.Hi.Hi,
+		u.Hi.Lo.Lo,
+		u.Hi.Lo.Hi,
+		u.Hi.Hi.Lo,
+		u.Hi.Hi.Hi,
+	}
+}
+
+// FromWords converts an array of 64-bit limbs in little-endian order
+// (the least significant limb goes first) to a Uint512 value.
+func FromWords(w [8]uint64) Uint512 {
+	return Uint512{
+		Lo: Uint256{
+			Lo: Uint128{Lo: w[0], Hi: w[1]},
+			Hi: Uint128{Lo: w[2], Hi: w[3]},
+		},
+		Hi: Uint256{
+			Lo: Uint128{Lo: w[4], Hi: w[5]},
+			Hi: Uint128{Lo: w[6], Hi: w[7]},
+		},
+	}
+}
+
+// IsZero returns true if stored 512-bit value is zero.
 func (u Uint512) IsZero() bool {
 	return u.Lo.IsZero() && u.Hi.IsZero()
 }
 
+// Equals returns true if two 512-bit values are equal.
+// Uint512 values can be compared directly with == operator
+// but use of the Equals method is preferred for consistency.
 func (u Uint512) Equals(v Uint512) bool {
 	return u.Lo.Equals(v.Lo) && u.Hi.Equals(v.Hi)
 }
 
+// Equals256 returns true if 512-bit value equals to a 256-bit value.
 func (u Uint512) Equals256(v Uint256) bool {
 	return u.Lo.Equals(v) && u.Hi.IsZero()
 }
 
+// Cmp compares two 512-bit values and returns:
+//
+//	-1 if u <  v
+//	 0 if u == v
+//	+1 if u >  v
 func (u Uint512) Cmp(v Uint512) int {
-	if h := u.Hi.Cmp(v.Hi); h != 0 {
-		return h
+	x, y := u.Words(), v.Words()
+	for i := uint64Count - 1; i >= 0; i-- {
+		switch {
+		case x[i] > y[i]:
+			return +1 // u > v
+		case x[i] < y[i]:
+			return -1 // u < v
+		}
 	}
-	return u.Lo.Cmp(v.Lo)
+	return 0 // u == v
 }
 
+// Cmp256 compares 512-bit and 256-bit values and returns:
+//
+//	-1 if u <  v
+//	 0 if u == v
+//	+1 if u >  v
 func (u Uint512) Cmp256(v Uint256) int {
 	if !u.Hi.IsZero() {
 		return +1 // u > v
@@ -131,6 +181,7 @@ func (u Uint512) Cmp256(v Uint256) int {
 ///////////////////////////////////////////////////////////////////////////////
 /// logical operators /////////////////////////////////////////////////////////
 
+// Not returns logical NOT (^u) of 512-bit value.
 func (u Uint512) Not() Uint512 {
 	return Uint512{
 		Lo: u.Lo.Not(),
@@ -138,6 +189,7 @@ func (u Uint512) Not() Uint512 {
 	}
 }
 
+// AndNot returns logical AND NOT (u&^v) of two 512-bit values.
 func (u Uint512) AndNot(v Uint512) Uint512 {
 	return Uint512{
 		Lo: u.Lo.AndNot(v.Lo),
@@ -145,6 +197,7 @@ func (u Uint512) AndNot(v Uint512) Uint512 {
 	}
 }
 
+// AndNot256 returns logical AND NOT (u&^v) of 512-bit and 256-bit values.
 func (u Uint512) AndNot256(v Uint256) Uint512 {
 	return Uint512{
 		Lo: u.Lo.AndNot(v),
@@ -152,6 +205,7 @@ func (u Uint512) AndNot256(v Uint256) Uint512 {
 	}
 }
 
+// And returns logical AND (u&v) of two 512-bit values.
 func (u Uint512) And(v Uint512) Uint512 {
 	return Uint512{
 		Lo: u.Lo.And(v.Lo),
@@ -159,6 +213,7 @@ func (u Uint512) And(v Uint512) Uint512 {
 	}
 }
 
+// And256 returns logical AND (u&v) of 512-bit and 256-bit values.
 func (u Uint512) And256(v Uint256) Uint512 {
 	return Uint512{
 		Lo: u.Lo.And(v),
@@ -166,6 +221,7 @@ func (u Uint512) And256(v Uint256) Uint512 {
 	}
 }
 
+// Or returns logical OR (u|v) of two 512-bit values.
 func (u Uint512) Or(v Uint512) Uint512 {
 	return Uint512{
 		Lo: u.Lo.Or(v.Lo),
@@ -173,6 +229,7 @@ func (u Uint512) Or(v Uint512) Uint512 {
 	}
 }
 
+// Or256 returns logical OR (u|v) of 512-bit and 256-bit values.
 func (u Uint512) Or256(v Uint256) Uint512 {
 	return Uint512{
 		Lo: u.Lo.Or(v),
@@ -180,6 +237,7 @@ func (u Uint512) Or256(v Uint256) Uint512 {
 	}
 }
 
+// Xor returns logical XOR (u^v) of two 512-bit values.
 func (u Uint512) Xor(v Uint512) Uint512 {
 	return Uint512{
 		Lo: u.Lo.Xor(v.Lo),
@@ -187,6 +245,7 @@ func (u Uint512) Xor(v Uint512) Uint512 {
 	}
 }
 
+// Xor256 returns logical XOR (u^v) of 512-bit and 256-bit values.
 func (u Uint512) Xor256(v Uint256) Uint512 {
 	return Uint512{
 		Lo: u.Lo.Xor(v),
@@ -197,38 +256,55 @@ func (u Uint512) Xor256(v Uint256) Uint512 {
 ///////////////////////////////////////////////////////////////////////////////
 /// arithmetic operators //////////////////////////////////////////////////////
 
+// Add returns the sum with carry of x, y and carry: sum = x + y + carry.
+// The carry input must be 0 or 1; otherwise the behavior is undefined.
+// The carryOut output is guaranteed to be 0 or 1.
 func Add(x, y Uint512, carry uint64) (sum Uint512, carryOut uint64) {
 	sum.Lo, carryOut = uint256.Add(x.Lo, y.Lo, carry)
 	sum.Hi, carryOut = uint256.Add(x.Hi, y.Hi, carryOut)
 	return
 }
 
+// Add returns sum (u+v) of two 512-bit values.
+// Wrap-around semantic is used here: Max().Add(From64(1)) == Zero()
 func (u Uint512) Add(v Uint512) Uint512 {
 	sum, _ := Add(u, v, 0)
 	return sum
 }
 
+// Add256 returns sum (u+v) of 512-bit and 256-bit values.
+// Wrap-around semantic is used here.
 func (u Uint512) Add256(v Uint256) Uint512 {
 	lo, c0 := uint256.Add(u.Lo, v, 0)
 	return Uint512{Lo: lo, Hi: u.Hi.Add128(Uint128{Lo: c0})}
 }
 
+// Sub returns the difference of x, y and borrow: diff = x - y - borrow.
+// The borrow input must be 0 or 1; otherwise the behavior is undefined.
+// The borrowOut output is guaranteed to be 0 or 1.
 func Sub(x, y Uint512, borrow uint64) (diff Uint512, borrowOut uint64) {
 	diff.Lo, borrowOut = uint256.Sub(x.Lo, y.Lo, borrow)
 	diff.Hi, borrowOut = uint256.Sub(x.Hi, y.Hi, borrowOut)
 	return
 }
 
+// Sub returns difference (u-v) of two 512-bit values.
+// Wrap-around semantic is used here: Zero().Sub(From64(1)) == Max().
 func (u Uint512) Sub(v Uint512) Uint512 {
 	diff, _ := Sub(u, v, 0)
 	return diff
 }
 
+// Sub256 returns difference (u-v) of 512-bit and 256-bit values.
+// Wrap-around semantic is used here.
 func (u Uint512) Sub256(v Uint256) Uint512 {
 	lo, b0 := uint256.Sub(u.Lo, v, 0)
 	return Uint512{Lo: lo, Hi: u.Hi.Sub128(Uint128{Lo: b0})}
 }
 
+// Mul returns the 1024-bit product of x and y: (hi, lo) = x * y
+// with the product bits' upper half returned in hi and the lower
+// half returned in lo.
 func Mul(x, y Uint512) (hi, lo Uint512) {
 	lo.Hi, lo.Lo = uint256.Mul(x.Lo, y.Lo)
 	hi.Hi, hi.Lo = uint256.Mul(x.Hi, y.Hi)
@@ -244,6 +320,8 @@ func Mul(x, y Uint512) (hi, lo Uint512) {
 	return
 }
 
+// Mul returns multiplication (u*v) of two 512-bit values.
+// Wrap-around semantic is used here: Max().Mul(Max()) == From64(1).
 func (u Uint512) Mul(v Uint512) Uint512 {
 	hi, lo := uint256.Mul(u.Lo, v.Lo)
 	hi = hi.Add(u.Hi.Mul(v.Lo))
@@ -251,6 +329,8 @@ func (u Uint512) Mul(v Uint512) Uint512 {
 	return Uint512{Lo: lo, Hi: hi}
 }
 
+// Mul256 returns multiplication (u*v) of 512-bit and 256-bit values.
+// Wrap-around semantic is used here.
 func (u Uint512) Mul256(v Uint256) Uint512 {
 	hi, lo := uint256.Mul(u.Lo, v)
 	return Uint512{
@@ -259,46 +339,55 @@ func (u Uint512) Mul256(v Uint256) Uint512 {
 	}
 }
 
+// Div returns division (u/v) of two 512-bit values.
 func (u Uint512) Div(v Uint512) Uint512 {
 	q, _ := u.QuoRem(v)
 	return q
 }
 
+// Div256 returns division (u/v) of 512-bit and 256-bit values.
 func (u Uint512) Div256(v Uint256) Uint512 {
 	q, _ := u.QuoRem256(v)
 	return q
 }
 
+// Div128 returns division (u/v) of 512-bit and 128-bit values.
 func (u Uint512) Div128(v Uint128) Uint512 {
 	q, _ := u.QuoRem128(v)
 	return q
 }
 
+// Div64 returns division (u/v) of 512-bit and 64-bit values.
 func (u Uint512) Div64(v uint64) Uint512 {
 	q, _ := u.QuoRem64(v)
 	return q
 }
 
+// Mod returns modulo (u%v) of two 512-bit values.
 func (u Uint512) Mod(v Uint512) Uint512 {
 	_, r := u.QuoRem(v)
 	return r
 }
 
+// Mod256 returns modulo (u%v) of 512-bit and 256-bit values.
 func (u Uint512) Mod256(v Uint256) Uint256 {
 	_, r := u.QuoRem256(v)
 	return r
 }
 
+// Mod128 returns modulo (u%v) of 512-bit and 128-bit values.
 func (u Uint512) Mod128(v Uint128) Uint128 {
 	_, r := u.QuoRem128(v)
 	return r
 }
 
+// Mod64 returns modulo (u%v) of 512-bit and 64-bit values.
 func (u Uint512) Mod64(v uint64) uint64 {
 	_, r := u.QuoRem64(v)
 	return r
 }
 
+// QuoRem returns quotient (u/v) and remainder (u%v) of two 512-bit values.
 func (u Uint512) QuoRem(v Uint512) (Uint512, Uint512) {
 	if v.Hi.IsZero() {
 		q, r := u.QuoRem256(v.Lo)
@@ -324,6 +413,7 @@ func (u Uint512) QuoRem(v Uint512) (Uint512, Uint512) {
 	return q, r
 }
 
+// QuoRem256 returns quotient (u/v) and remainder (u%v) of 512-bit and 256-bit values.
 func (u Uint512) QuoRem256(v Uint256) (Uint512, Uint256) {
 	if u.Hi.Cmp(v) < 0 {
 		lo, r := uint256.Div(u.Hi, u.Lo, v)
@@ -335,6 +425,7 @@ func (u Uint512) QuoRem256(v Uint256) (Uint512, Uint256) {
 	return Uint512{Lo: lo, Hi: hi}, r
 }
 
+// QuoRem128 returns quotient (u/v) and remainder (u%v) of 512-bit and 128-bit values.
 func (u Uint512) QuoRem128(v Uint128) (q Uint512, r Uint128) {
 	q.Hi, r = u.Hi.QuoRem128(v)
 
@@ -343,6 +434,7 @@ func (u Uint512) QuoRem128(v Uint128) (q Uint512, r Uint128) {
 	return
 }
 
+// QuoRem64 returns quotient (u/v) and remainder (u%v) of 512-bit and 64-bit values.
 func (u Uint512) QuoRem64(v uint64) (q Uint512, r uint64) {
 	q.Hi, r = u.Hi.QuoRem64(v)
 
@@ -354,6 +446,10 @@ func (u Uint512) QuoRem64(v uint64) (q Uint512, r uint64) {
 	return
 }
 
+// Div returns the quotient and remainder of (hi, lo) divided by y:
+// quo = (hi, lo)/y, rem = (hi, lo)%y with the dividend bits' upper
+// half in parameter hi and the lower half in parameter lo.
+// Panics if y is less or equal to hi!
 func Div(hi, lo, y Uint512) (quo, rem Uint512) {
 	if y.IsZero() {
 		panic(errors.New("integer divide by zero"))
@@ -365,7 +461,7 @@ func Div(hi, lo, y Uint512) (quo, rem Uint512) {
 	s := uint(y.LeadingZeros())
 	y = y.Lsh(s)
 
-	un32 := hi.Lsh(s).Or(lo.Rsh(256 - s))
+	un32 := hi.Lsh(s).Or(lo.Rsh(bitCount - s))
 	un10 := lo.Lsh(s)
 	q1, rhat := un32.QuoRem256(y.Hi)
 	r1 := From256(rhat)
@@ -395,186 +491,103 @@ func Div(hi, lo, y Uint512) (quo, rem Uint512) {
 			Sub(q0.Mul(y)).Rsh(s)
 }
 
+// Lsh returns left shift (u<<n).
 func (u Uint512) Lsh(n uint) Uint512 {
-	if n == 0 {
-		return u
-	}
 	if n >= bitCount {
 		return Zero()
 	}
 
-	if n > 256 {
-		return Uint512{
-			Lo: Uint256{},
-			Hi: u.Lo.Lsh(n - 256),
-		}
-	}
-
-	if n > 128 {
-		return Uint512{
-			Lo: Uint256{
-				Lo: Uint128{},
-				Hi: u.Lo.Lo.Lsh(n - 128),
-			},
-			Hi: Uint256{
-				Lo: u.Lo.Hi.Lsh(n - 128).Or(u.Lo.Lo.Rsh(256 - n)),
-				Hi: u.Hi.Lo.Lsh(n - 128).Or(u.Lo.Hi.Rsh(256 - n)),
-			},
-		}
+	w := u.Words()
+	k, m := int(n/64), n%64
+	var r [uint64Count]uint64
+	if m == 0 {
+		copy(r[k:], w[:uint64Count-k])
+		return FromWords(r)
 	}
 
-	if n > 64 {
-		return Uint512{
-			Lo: Uint256{
-				Lo: Uint128{
-					Lo: 0,
-					Hi: u.Lo.Lo.Lo << (n - 64),
-				},
-				Hi: Uint128{
-					Lo: u.Lo.Lo.Hi<<(n-64) | u.Lo.Lo.Lo>>(128-n),
-					Hi: u.Lo.Hi.Lo<<(n-64) | u.Lo.Lo.Hi>>(128-n),
-				},
-			},
-			Hi: Uint256{
-				Lo: Uint128{
-					Lo: u.Lo.Hi.Hi<<(n-64) | u.Lo.Hi.Lo>>(128-n),
-					Hi: u.Hi.Lo.Lo<<(n-64) | u.Lo.Hi.Hi>>(128-n),
-				},
-				Hi: Uint128{
-					Lo: u.Hi.Lo.Hi<<(n-64) | u.Hi.Lo.Lo>>(128-n),
-					Hi: u.Hi.Hi.Lo<<(n-64) | u.Hi.Lo.Hi>>(128-n),
-				},
-			},
-		}
-	}
-
-	return Uint512{
-		Lo: Uint256{
-			Lo: Uint128{
-				Lo: u.Lo.Lo.Lo << n,
-				Hi: u.Lo.Lo.Hi<<n | u.Lo.Lo.Lo>>(64-n),
-			},
-			Hi: Uint128{
-				Lo: u.Lo.Hi.Lo<<n | u.Lo.Lo.Hi>>(64-n),
-				Hi: u.Lo.Hi.Hi<<n | u.Lo.Hi.Lo>>(64-n),
-			},
-		},
-		Hi: Uint256{
-			Lo: Uint128{
-				Lo: u.Hi.Lo.Lo<<n | u.Lo.Hi.Hi>>(64-n),
-				Hi: u.Hi.Lo.Hi<<n | u.Hi.Lo.Lo>>(64-n),
-			},
-			Hi: Uint128{
-				Lo: u.Hi.Hi.Lo<<n | u.Hi.Lo.Hi>>(64-n),
-				Hi: u.Hi.Hi.Hi<<n | u.Hi.Hi.Lo>>(64-n),
-			},
-		},
+	for i := uint64Count - 1; i > k; i-- {
+		r[i] = w[i-k]<<m | w[i-k-1]>>(64-m)
 	}
+	r[k] = w[0] << m
+	return FromWords(r)
 }
 
+// Rsh returns right shift (u>>n).
 func (u Uint512) Rsh(n uint) Uint512 {
-	if n == 0 {
-		return u
-	}
 	if n >= bitCount {
 		return Zero()
 	}
 
-	if n > 256 {
-		return Uint512{
-			Lo: u.Hi.Rsh(n - 256),
-			Hi: Uint256{},
-		}
+	w := u.Words()
+	k, m := int(n/64), n%64
+	var r [uint64Count]uint64
+	if m == 0 {
+		copy(r[:uint64Count-k], w[k:])
+		return FromWords(r)
 	}
 
-	if n > 128 {
-		return Uint512{
-			Lo: Uint256{
-				Lo: u.Lo.Hi.Rsh(n - 128).Or(u.Hi.Lo.Lsh(256 - n)),
-				Hi: u.Hi.Lo.Rsh(n - 128).Or(u.Hi.Hi.Lsh(256 - n)),
-			},
-			Hi: Uint256{
-				Lo: u.Hi.Hi.Rsh(n - 128),
-				Hi: Uint128{},
-			},
-		}
+	last := uint64Count - 1 - k
+	for i := 0; i < last; i++ {
+		r[i] = w[i+k]>>m | w[i+k+1]<<(64-m)
 	}
+	r[last] = w[uint64Count-1] >> m
+	return FromWords(r)
+}
 
-	if n > 64 {
-		return Uint512{
-			Lo: Uint256{
-				Lo: Uint128{
-					Lo: u.Lo.Lo.Hi>>(n-64) | u.Lo.Hi.Lo<<(128-n),
-					Hi: u.Lo.Hi.Lo>>(n-64) | u.Lo.Hi.Hi<<(128-n),
-				},
-				Hi: Uint128{
-					Lo: u.Lo.Hi.Hi>>(n-64) | u.Hi.Lo.Lo<<(128-n),
-					Hi: u.Hi.Lo.Lo>>(n-64) | u.Hi.Lo.Hi<<(128-n),
-				},
-			},
-			Hi: Uint256{
-				Lo: Uint128{
-					Lo: u.Hi.Lo.Hi>>(n-64) | u.Hi.Hi.Lo<<(128-n),
-					Hi: u.Hi.Hi.Lo>>(n-64) | u.Hi.Hi.Hi<<(128-n),
-				},
-				Hi: Uint128{
-					Lo: u.Hi.Hi.Hi >> (n - 64),
-					Hi: 0,
-				},
-			},
-		}
+// RotateLeft returns the value of u rotated left by (k mod 512) bits.
+func (u Uint512) RotateLeft(k int) Uint512 {
+	n := uint(k) & (bitCount - 1)
+	if n == 0 {
+		return u // no shift
 	}
+	return u.Lsh(n).Or(u.Rsh(bitCount - n))
+}
 
-	return Uint512{
-		Lo: Uint256{
-			Lo: Uint128{
-				Lo: u.Lo.Lo.Lo>>n | u.Lo.Lo.Hi<<(64-n),
-				Hi: u.Lo.Lo.Hi>>n | u.Lo.Hi.Lo<<(64-n),
-			},
-			Hi: Uint128{
-				Lo: u.Lo.Hi.Lo>>n | u.Lo.Hi.Hi<<(64-n),
-				Hi: u.Lo.Hi.Hi>>n | u.Hi.Lo.Lo<<(64-n),
-			},
-		},
-		Hi: Uint256{
-			Lo: Uint128{
-				Lo: u.Hi.Lo.Lo>>n | u.Hi.Lo.Hi<<(64-n),
-				Hi: u.Hi.Lo.Hi>>n | u.Hi.Hi.Lo<<(64-n),
-			},
-			Hi: Uint128{
-				Lo: u.Hi.Hi.Lo>>n | u.Hi.Hi.Hi<<(64-n),
-				Hi: u.Hi.Hi.Hi >> n,
-			},
-		},
-	}
+// RotateRight returns the value of u rotated right by (k mod 512) bits.
+func (u Uint512) RotateRight(k int) Uint512 {
+	return u.RotateLeft(-k)
 }
 
+// BitLen returns the minimum number of bits required to represent 512-bit value.
+// The result is 0 for u == 0.
 func (u Uint512) BitLen() int {
-	if !u.Hi.IsZero() {
-		return 256 + u.Hi.BitLen()
+	w := u.Words()
+	for i := uint64Count - 1; i >= 0; i-- {
+		if w[i] != 0 {
+			return i*64 + bits.Len64(w[i])
+		}
 	}
-	return u.Lo.BitLen()
+	return 0
 }
 
+// LeadingZeros returns the number of leading zero bits.
+// The result is 512 for u == 0.
 func (u Uint512) LeadingZeros() int {
-	if !u.Hi.IsZero() {
-		return u.Hi.LeadingZeros()
-	}
-	return 256 + u.Lo.LeadingZeros()
+	return bitCount - u.BitLen()
 }
 
+// TrailingZeros returns the number of trailing zero bits.
+// The result is 512 for u == 0.
 func (u Uint512) TrailingZeros() int {
-	if !u.Lo.IsZero() {
-		return u.Lo.TrailingZeros()
+	w := u.Words()
+	for i := 0; i < uint64Count; i++ {
+		if w[i] != 0 {
+			return i*64 + bits.TrailingZeros64(w[i])
+		}
 	}
-	return 256 + u.Hi.TrailingZeros()
+	return bitCount
 }
 
+// OnesCount returns the number of one bits ("population count").
 func (u Uint512) OnesCount() int {
-	return u.Lo.OnesCount() +
-		u.Hi.OnesCount()
+	var n int
+	for _, x := range u.Words() {
+		n += bits.OnesCount64(x)
+	}
+	return n
 }
 
+// Reverse returns the value with bits in reversed order.
 func (u Uint512) Reverse() Uint512 {
 	return Uint512{
 		Lo: u.Hi.Reverse(),
@@ -582,6 +595,7 @@ func (u Uint512) Reverse() Uint512 {
 	}
 }
 
+// ReverseBytes returns the value with bytes in reversed order.
 func (u Uint512) ReverseBytes() Uint512 {
 	return Uint512{
 		Lo: u.Hi.ReverseBytes(),
@@ -589,11 +603,11 @@ func (u Uint512) ReverseBytes() Uint512 {
 	}
 }
 
+// Bit returns true if the n-th bit of value is set, i.e. (u>>n)&1 == 1.
+// The result is false if n is out of [0, 512) range.
 func (u Uint512) Bit(n int) bool {
 	if n < 0 || n >= bitCount {
 		return false
 	}
-	word := n / 64
-	bit := uint(n % 64)
-	return ((*(*[uint64Count]uint64)(unsafe.Pointer(&u)))[word]>>bit)&1 == 1
+	return (u.Words()[n/64]>>uint(n%64))&1 == 1
 }
@@ -0,0 +1,40 @@
+package uint512
+
+import (
+	"github.com/piliming/bigz/alphabet"
+	"github.com/piliming/bigz/internal/nat"
+)
+
+// EncodeBase returns u in the alphabet, e.g. alphabet.Base58,
+// without leading zero digits. Zero is a single zero digit.
+func EncodeBase(u Uint512, a *alphabet.Alphabet) string {
+	return string(AppendBase(nil, u, a, false))
+}
+
+// EncodeBasePadded returns u in the alphabet padded with zero digits
+// to the length of Max(), so that encodings sort as values.
+func EncodeBasePadded(u Uint512, a *alphabet.Alphabet) string {
+	return string(AppendBase(nil, u, a, true))
+}
+
+// AppendBase appends u in the alphabet to dst, optionally padded
+// to the fixed length, see EncodeBase and EncodeBasePadded.
+func AppendBase(dst []byte, u Uint512, a *alphabet.Alphabet, padded bool) []byte {
+	width := 0
+	if padded {
+		width = nat.AlphabetLen(bitCount, a.Radix())
+	}
+	w := u.Words()
+	return nat.AppendAlphabet(dst, w[:], a.String(), width)
+}
+
+// DecodeBase interprets s in the alphabet, padded or not. Invalid digits,
+// empty s and s longer than the padded length are *strconv.NumError
+// with strconv.ErrSyntax, values overflowing 512-bit are strconv.ErrRange.
+func DecodeBase(s string, a *alphabet.Alphabet) (Uint512, error) {
+	var w [uint64Count]uint64
+	if err := nat.ParseAlphabet("DecodeBase", w[:], s, a.Radix(), a.Value); err != nil {
+		return FromWords(w), err
+	}
+	return FromWords(w), nil
+}
@@ -0,0 +1,158 @@
+package uint512
+
+import (
+	"fmt"
+	"io"
+)
+
+// The binary layout of Uint512 is fixed: 64 bytes in big-endian byte order,
+// i.e. the most significant byte goes first. The compact layout is the same
+// but without leading zero bytes, just like (*big.Int).Bytes.
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// The result is always 64 bytes in big-endian byte order.
+func (u Uint512) MarshalBinary() ([]byte, error) {
+	return u.AppendBinary(make([]byte, 0, 64))
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+// The data must be exactly 64 bytes in big-endian byte order.
+func (u *Uint512) UnmarshalBinary(data []byte) error {
+	if len(data) != 64 {
+		return fmt.Errorf("invalid 512-bit binary length %d, expected 64", len(data))
+	}
+	*u = LoadBigEndian(data)
+	return nil
+}
+
+// GobEncode implements the gob.GobEncoder interface.
+// The layout is the same as of MarshalBinary.
+func (u Uint512) GobEncode() ([]byte, error) {
+	return u.MarshalBinary()
+}
+
+// GobDecode implements the gob.GobDecoder interface.
+// The layout is the same as of UnmarshalBinary.
+func (u *Uint512) GobDecode(data []byte) error {
+	return u.UnmarshalBinary(data)
+}
+
+// Bytes returns the compact big-endian representation of u,
+// i.e. without leading zero bytes. The result is empty for zero.
+func (u Uint512) Bytes() []byte {
+	var buf [64]byte
+	StoreBigEndian(buf[:], u)
+	i := 0
+	for i < len(buf) && buf[i] == 0 {
+		i++
+	}
+	return append([]byte{}, buf[i:]...)
+}
+
+// FromBytes converts the big-endian byte slice of any length to Uint512.
+// Leading zero bytes are ignored, so it is the inverse of Bytes and
+// of MarshalBinary. If the value overflows 512-bit then ok=false
+// and Max() is returned.
+func FromBytes(b []byte) (Uint512, bool) {
+	for len(b) > 0 && b[0] == 0 {
+		b = b[1:]
+	}
+	if len(b) > 64 {
+		return Max(), false // value overflows 512-bit!
+	}
+	var buf [64]byte
+	copy(buf[64-len(b):], b)
+	return LoadBigEndian(buf[:]), true
+}
+
+// FillBytes sets buf to u, storing it as a zero-extended big-endian byte slice,
+// and returns buf. Just like (*big.Int).FillBytes, buf may be shorter than 64
+// bytes if the value fits, otherwise it panics.
+func (u Uint512) FillBytes(buf []byte) []byte {
+	var tmp [64]byte
+	StoreBigEndian(tmp[:], u)
+	if n := len(tmp) - len(buf); n > 0 {
+		for _, c := range tmp[:n] {
+			if c != 0 {
+				panic("uint512: buffer too small to fit value")
+			}
+		}
+		copy(buf, tmp[n:])
+	} else {
+		for i := range buf[:-n] {
+			buf[i] = 0
+		}
+		copy(buf[-n:], tmp[:])
+	}
+	return buf
+}
+
+// SetBytes sets u to the value of big-endian byte slice of any length
+// and reports whether there is no overflow. It is the same as FromBytes.
+func (u *Uint512) SetBytes(b []byte) (ok bool) {
+	*u, ok = FromBytes(b)
+	return ok
+}
+
+// StoreLittleEndianArray stores 512-bit value in byte array in little-endian byte order.
+func StoreLittleEndianArray(b *[64]byte, u Uint512) {
+	StoreLittleEndian(b[:], u)
+}
+
+// StoreBigEndianArray stores 512-bit value in byte array in big-endian byte order.
+func StoreBigEndianArray(b *[64]byte, u Uint512) {
+	StoreBigEndian(b[:], u)
+}
+
+// LoadLittleEndianArray loads 512-bit value from byte array in little-endian byte order.
+func LoadLittleEndianArray(b *[64]byte) Uint512 {
+	return LoadLittleEndian(b[:])
+}
+
+// LoadBigEndianArray loads 512-bit value from byte array in big-endian byte order.
+func LoadBigEndianArray(b *[64]byte) Uint512 {
+	return LoadBigEndian(b[:])
+}
+
+// StoreLittleEndianChecked stores 512-bit value in byte slice in little-endian byte order.
+// Unlike StoreLittleEndian it returns an error if byte slice length is less than 64.
+func StoreLittleEndianChecked(b []byte, u Uint512) error {
+	if len(b) < 64 {
+		return shortBufferError(len(b))
+	}
+	StoreLittleEndian(b, u)
+	return nil
+}
+
+// StoreBigEndianChecked stores 512-bit value in byte slice in big-endian byte order.
+// Unlike StoreBigEndian it returns an error if byte slice length is less than 64.
+func StoreBigEndianChecked(b []byte, u Uint512) error {
+	if len(b) < 64 {
+		return shortBufferError(len(b))
+	}
+	StoreBigEndian(b, u)
+	return nil
+}
+
+// LoadLittleEndianChecked loads 512-bit value from byte slice in little-endian byte order.
+// Unlike LoadLittleEndian it returns an error if byte slice length is less than 64.
+func LoadLittleEndianChecked(b []byte) (Uint512, error) {
+	if len(b) < 64 {
+		return Zero(), shortBufferError(len(b))
+	}
+	return LoadLittleEndian(b), nil
+}
+
+// LoadBigEndianChecked loads 512-bit value from byte slice in big-endian byte order.
+// Unlike LoadBigEndian it returns an error if byte slice length is less than 64.
+func LoadBigEndianChecked(b []byte) (Uint512, error) {
+	if len(b) < 64 {
+		return Zero(), shortBufferError(len(b))
+	}
+	return LoadBigEndian(b), nil
+}
+
+// shortBufferError returns io.ErrShortBuffer based error.
+func shortBufferError(n int) error {
+	return fmt.Errorf("%d bytes is not enough for 512-bit integer: %w", n, io.ErrShortBuffer)
+}
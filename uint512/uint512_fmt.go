@@ -2,42 +2,62 @@ package uint512
 
 import (
 	"fmt"
+	"github.com/piliming/bigz/internal/nat"
 	"github.com/piliming/bigz/uint256"
 	"math/big"
 )
 
-// FromString parses input string as a Uint256 value.
+// FromString parses input string as a Uint512 value.
 func FromString(s string) (Uint512, error) {
 	var u Uint512
 	_, err := fmt.Sscan(s, &u)
 	return u, err
 }
 
-func (u Uint512) String() string {
-	if u.Hi.IsZero() {
-		return u.Lo.String()
-	}
+// ParseUint interprets a string s in the given base (0, 2 to 36) and
+// returns the corresponding 512-bit value. It works like strconv.ParseUint:
+// if base is 0, it is implied by the prefix (0b, 0o or 0, 0x) and
+// underscores are permitted as digit separators.
+//
+// The error is *strconv.NumError. On overflow, the result is Max()
+// and the error is strconv.ErrRange. There is no memory allocation
+// unless an error is returned.
+func ParseUint(s string, base int) (Uint512, error) {
+	var w [8]uint64
+	err := nat.ParseUint(w[:], s, base)
+	return FromWords(w), err
+}
 
-	// log10(2^512) ≈ 154, so a buffer of 156 is enough
-	buf := []byte("000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000") // len=156
-	for i := len(buf); ; i -= 19 {
-		q, r := u.QuoRem64(1e19)
-		var n int
-		for ; r != 0; r /= 10 {
-			n++
-			buf[i-n] += byte(r % 10)
-		}
-
-		if q.IsZero() {
-			return string(buf[i-n:])
-		}
-		u = q
-	}
+// FormatUint returns the string representation of u in the given base,
+// for 2 <= base <= 36. The result uses the lower-case letters 'a' to 'z'
+// for digit values >= 10. It panics if base is out of range.
+func FormatUint(u Uint512, base int) string {
+	w := u.Words()
+	return string(nat.AppendDigits(nil, w[:], base))
+}
+
+// AppendUint appends the string form of u, as generated by FormatUint,
+// to dst and returns the extended buffer.
+func AppendUint(dst []byte, u Uint512, base int) []byte {
+	w := u.Words()
+	return nat.AppendDigits(dst, w[:], base)
+}
+
+// String returns the base-10 representation of 512-bit value.
+func (u Uint512) String() string {
+	var buf [155]byte // log10(2^512) < 155
+	w := u.Words()
+	return string(nat.AppendDigits(buf[:0], w[:], 10))
 }
 
 // Format does custom formatting of 512-bit value.
+//
+// The output is the same as of (*big.Int).Format for all supported verbs:
+// 'b', 'o', 'O', 'd', 's', 'v', 'x' and 'X', including width, precision
+// and '#', '+', '-', '0', ' ' flags.
 func (u Uint512) Format(s fmt.State, ch rune) {
-	u.Big().Format(s, ch) // via big.Int, unefficient! consider to optimize
+	w := u.Words()
+	nat.Format(s, ch, w[:])
 }
 
 // Scan implements fmt.Scanner.
@@ -57,53 +77,86 @@ func (u *Uint512) Scan(s fmt.ScanState, ch rune) error {
 }
 
 // MarshalText implements the encoding.TextMarshaler interface.
+// The text is the base-10 representation, the same as String().
 func (u Uint512) MarshalText() (text []byte, err error) {
-	return u.Big().MarshalText() // via big.Int, unefficient! consider to optimize
+	return u.AppendText(nil)
+}
+
+// AppendText implements the encoding.TextAppender interface.
+// It appends the same text as MarshalText to dst.
+func (u Uint512) AppendText(dst []byte) ([]byte, error) {
+	return u.AppendDecimal(dst), nil
+}
+
+// AppendDecimal appends the base-10 representation of u to dst.
+func (u Uint512) AppendDecimal(dst []byte) []byte {
+	w := u.Words()
+	return nat.AppendDigits(dst, w[:], 10)
+}
+
+// AppendHex appends the lower case base-16 representation of u to dst,
+// without "0x" prefix. If padded is true, exactly 128 digits are appended.
+func (u Uint512) AppendHex(dst []byte, padded bool) []byte {
+	w := u.Words()
+	if padded {
+		return nat.AppendHexPadded(dst, w[:])
+	}
+	return nat.AppendDigits(dst, w[:], 16)
+}
+
+// AppendBinary implements the encoding.BinaryAppender interface.
+// It appends 64 bytes of u to dst in big-endian byte order.
+func (u Uint512) AppendBinary(dst []byte) ([]byte, error) {
+	w := u.Words()
+	return nat.AppendBigEndian(dst, w[:]), nil
 }
 
 // UnmarshalText implements the encoding.TextUnmarshaler interface.
+// It accepts the same text as ParseUint with base 0, e.g. "123" or "0x7b".
+// Unlike FromString and Scan, which go through big.Int, a sign is not
+// accepted: "+1" is a syntax error. The error is *strconv.NumError. There is no memory allocation unless
+// an error is returned.
 func (u *Uint512) UnmarshalText(text []byte) error {
-	// via big.Int, unefficient! consider to optimize
-	i := new(big.Int)
-	if err := i.UnmarshalText(text); err != nil {
+	var w [8]uint64
+	if err := nat.ParseUint(w[:], text, 0); err != nil {
 		return err
 	}
-	v, ok := FromBigEx(i)
-	if !ok {
-		return fmt.Errorf("%q overflows 512-bit integer", text)
-	}
-	*u = v
+	*u = FromWords(w)
 	return nil
 }
 
-//// StoreLittleEndian stores 256-bit value in byte slice in little-endian byte order.
-//// It panics if byte slice length is less than 32.
-//func StoreLittleEndian(b []byte, u Uint256) {
-//	uint128.StoreLittleEndian(b[:16], u.Lo)
-//	uint128.StoreLittleEndian(b[16:], u.Hi)
-//}
-//
-//// StoreBigEndian stores 256-bit value in byte slice in big-endian byte order.
-//// It panics if byte slice length is less than 32.
-//func StoreBigEndian(b []byte, u Uint256) {
-//	uint128.StoreBigEndian(b[:16], u.Hi)
-//	uint128.StoreBigEndian(b[16:], u.Lo)
-//}
-
-// LoadLittleEndian loads 256-bit value from byte slice in little-endian byte order.
-// It panics if byte slice length is less than 32.
-func LoadLittleEndian(b [64]byte) Uint512 {
+// StoreLittleEndian stores 512-bit value in byte slice in little-endian byte order.
+// It panics if byte slice length is less than 64.
+func StoreLittleEndian(b []byte, u Uint512) {
+	_ = b[byteCount-1] // early bounds check
+	uint256.StoreLittleEndian(b[:byteCount/2], u.Lo)
+	uint256.StoreLittleEndian(b[byteCount/2:], u.Hi)
+}
+
+// StoreBigEndian stores 512-bit value in byte slice in big-endian byte order.
+// It panics if byte slice length is less than 64.
+func StoreBigEndian(b []byte, u Uint512) {
+	_ = b[byteCount-1] // early bounds check
+	uint256.StoreBigEndian(b[:byteCount/2], u.Hi)
+	uint256.StoreBigEndian(b[byteCount/2:], u.Lo)
+}
+
+// LoadLittleEndian loads 512-bit value from byte slice in little-endian byte order.
+// It panics if byte slice length is less than 64.
+func LoadLittleEndian(b []byte) Uint512 {
+	_ = b[byteCount-1] // early bounds check
 	return Uint512{
-		Lo: uint256.LoadLittleEndian(b[:32]),
-		Hi: uint256.LoadLittleEndian(b[32:]),
+		Lo: uint256.LoadLittleEndian(b[:byteCount/2]),
+		Hi: uint256.LoadLittleEndian(b[byteCount/2:]),
 	}
 }
 
-//// LoadBigEndian loads 256-bit value from byte slice in big-endian byte order.
-//// It panics if byte slice length is less than 32.
-//func LoadBigEndian(b []byte) Uint256 {
-//	return Uint256{
-//		Lo: uint128.LoadBigEndian(b[16:]),
-//		Hi: uint128.LoadBigEndian(b[:16]),
-//	}
-//}
+// LoadBigEndian loads 512-bit value from byte slice in big-endian byte order.
+// It panics if byte slice length is less than 64.
+func LoadBigEndian(b []byte) Uint512 {
+	_ = b[byteCount-1] // early bounds check
+	return Uint512{
+		Lo: uint256.LoadBigEndian(b[byteCount/2:]),
+		Hi: uint256.LoadBigEndian(b[:byteCount/2]),
+	}
+}
@@ -0,0 +1,13 @@
+//go:build purego || !(386 || amd64 || arm || arm64 || loong64 || mips64le || mipsle || ppc64le || riscv64 || wasm)
+
+package uint512
+
+import "encoding/binary"
+
+// littleEndianBytes returns 512-bit value in little-endian byte order.
+func (u Uint512) littleEndianBytes() (b [byteCount]byte) {
+	for i, w := range u.Words() {
+		binary.LittleEndian.PutUint64(b[8*i:], w)
+	}
+	return
+}
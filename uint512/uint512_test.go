@@ -3,12 +3,22 @@
 package uint512
 
 import (
+	"bytes"
+	"encoding/asn1"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"math/big"
 	"math/bits"
 	"math/rand"
 	"slices"
+	"strconv"
+	"strings"
 	"testing"
+
+	"github.com/piliming/bigz/alphabet"
 )
 
 func rand512slice(count int) []Uint512 {
@@ -26,7 +36,7 @@ func rand512() Uint512 {
 		buf[i] = byte(rand.Uint64() % 256)
 	}
 
-	return LoadLittleEndian(buf)
+	return LoadLittleEndian(buf[:])
 }
 
 func assertString(t *testing.T, got, want string, prefix string) {
@@ -281,3 +291,483 @@ func TestUint512_FromBig(t *testing.T) {
 
 	}
 }
+
+func TestUint512_Words(t *testing.T) {
+	for i := 0; i < loopTimes/100; i++ {
+		val := rand512()
+		bigVal := val.Big()
+
+		words := val.Words()
+		for j, w := range words {
+			want := new(big.Int).Rsh(bigVal, uint(64*j)).Uint64()
+			assertString(t, fmt.Sprintf("%x", w), fmt.Sprintf("%x", want), fmt.Sprintf("Words-%d", j))
+		}
+
+		assertBool(t, FromWords(words).Equals(val), true, "FromWords")
+	}
+}
+
+func TestUint512_Cmp(t *testing.T) {
+	for i := 0; i < loopTimes/100; i++ {
+		x, y := rand512(), rand512()
+		if i%2 == 0 {
+			y = x.Xor(From64(uint64(i) & 0xFF)) // differ in the lowest limb only
+		}
+
+		assertInt(t, x.Cmp(y), x.Big().Cmp(y.Big()), "Cmp")
+		assertInt(t, x.Cmp(x), 0, "Cmp-self")
+	}
+}
+
+func TestUint512_BitCount(t *testing.T) {
+	for i := 0; i < loopTimes/100; i++ {
+		val := rand512().Rsh(uint(i % bitCount))
+		bigVal := val.Big()
+
+		assertInt(t, val.BitLen(), bigVal.BitLen(), "BitLen")
+		assertInt(t, val.LeadingZeros(), bitCount-bigVal.BitLen(), "LeadingZeros")
+
+		ones := 0
+		for j := 0; j < bitCount; j++ {
+			ones += int(bigVal.Bit(j))
+		}
+		assertInt(t, val.OnesCount(), ones, "OnesCount")
+	}
+
+	assertInt(t, Zero().BitLen(), 0, "BitLen-zero")
+	assertInt(t, Zero().LeadingZeros(), bitCount, "LeadingZeros-zero")
+	assertInt(t, Zero().TrailingZeros(), bitCount, "TrailingZeros-zero")
+}
+
+func TestUint512_QuoRemSmall(t *testing.T) {
+	for i := 0; i < loopTimes/100; i++ {
+		val := rand512().Rsh(uint(rand.Intn(bitCount)))
+		bigVal := val.Big()
+
+		// divisor fits lower half, so full-width Div is used
+		divVal := rand512().Rsh(uint(bitCount/2 + rand.Intn(bitCount/2)))
+		if divVal.IsZero() {
+			continue
+		}
+		divBigVal := divVal.Big()
+
+		q, r := val.QuoRem(divVal)
+		qBig, rBig := new(big.Int).QuoRem(bigVal, divBigVal, new(big.Int))
+
+		assertString(t, q.String(), qBig.String(), "q")
+		assertString(t, r.String(), rBig.String(), "r")
+	}
+}
+
+func TestUint512_FullDiv(t *testing.T) {
+	for i := 0; i < loopTimes/100; i++ {
+		hi, lo := rand512(), rand512()
+		y := rand512().Rsh(uint(rand.Intn(bitCount)))
+		if y.Cmp(hi) <= 0 {
+			hi, y = y, hi
+		}
+		if y.Cmp(hi) <= 0 {
+			continue
+		}
+
+		q, r := Div(hi, lo, y)
+		hilo := new(big.Int).Lsh(hi.Big(), bitCount)
+		hilo.Or(hilo, lo.Big())
+		qBig, rBig := new(big.Int).QuoRem(hilo, y.Big(), new(big.Int))
+
+		assertString(t, q.String(), qBig.String(), "q")
+		assertString(t, r.String(), rBig.String(), "r")
+	}
+}
+
+func TestUint512_Rotate(t *testing.T) {
+	for i := 0; i < loopTimes/100; i++ {
+		val := rand512()
+		bigVal := val.Big()
+		k := rand.Intn(3*bitCount) - bitCount
+		n := uint(k) % bitCount
+
+		// (u << n) | (u >> (N - n))
+		want := new(big.Int).Lsh(bigVal, n)
+		want.Or(want, new(big.Int).Rsh(bigVal, bitCount-n))
+		want.And(want, maxBigIntUint512)
+
+		assertString(t, val.RotateLeft(k).String(), want.String(), fmt.Sprintf("RotateLeft-%d", k))
+		assertString(t, val.RotateLeft(k).RotateRight(k).String(), bigVal.String(), fmt.Sprintf("RotateRight-%d", k))
+	}
+}
+
+func TestUint512_StoreLoad(t *testing.T) {
+	for i := 0; i < loopTimes/100; i++ {
+		val := rand512()
+		buf := make([]byte, byteCount)
+
+		StoreLittleEndian(buf, val)
+		assertBool(t, LoadLittleEndian(buf).Equals(val), true, "LoadLittleEndian")
+
+		StoreBigEndian(buf, val)
+		assertBool(t, LoadBigEndian(buf).Equals(val), true, "LoadBigEndian")
+		assertString(t, fmt.Sprintf("%x", buf), fmt.Sprintf("%0128x", val.Big()), "StoreBigEndian")
+		assertBool(t, LoadLittleEndian(buf).Equals(val.ReverseBytes()), true, "ReverseBytes")
+	}
+}
+
+func TestUint512_ParseUint(t *testing.T) {
+	for i := 0; i < loopTimes/1000; i++ {
+		val := rand512()
+		base := 2 + rand.Intn(35)
+
+		s := FormatUint(val, base)
+		assertString(t, s, val.Big().Text(base), fmt.Sprintf("FormatUint-%d", base))
+		assertString(t, string(AppendUint([]byte("0x"), val, base)), "0x"+s, fmt.Sprintf("AppendUint-%d", base))
+
+		got, err := ParseUint(s, base)
+		assertBool(t, err == nil, true, fmt.Sprintf("ParseUint-%d error", base))
+		assertString(t, got.String(), val.String(), fmt.Sprintf("ParseUint-%d", base))
+	}
+
+	got, err := ParseUint("0x_"+strings.Repeat("ff_", byteCount-1)+"ff", 0)
+	assertBool(t, err == nil, true, "ParseUint underscores")
+	assertBool(t, got.Equals(Max()), true, "ParseUint Max")
+
+	got, err = ParseUint("0x1"+strings.Repeat("0", 2*byteCount), 0)
+	assertBool(t, errors.Is(err, strconv.ErrRange), true, "ParseUint overflow")
+	assertBool(t, got.Equals(Max()), true, "ParseUint overflow Max")
+
+	_, err = ParseUint("1_0", 10)
+	assertBool(t, errors.Is(err, strconv.ErrSyntax), true, "ParseUint syntax")
+
+	s := Max().String()
+	allocs := testing.AllocsPerRun(100, func() { _, _ = ParseUint(s, 10) })
+	assertInt(t, int(allocs), 0, "ParseUint allocs")
+
+	var u Uint512
+	text := []byte("0x" + FormatUint(Max(), 16))
+	allocs = testing.AllocsPerRun(100, func() { _ = u.UnmarshalText(text) })
+	assertInt(t, int(allocs), 0, "UnmarshalText allocs")
+	assertBool(t, u.Equals(Max()), true, "UnmarshalText hex")
+
+	err = u.UnmarshalText([]byte("+1"))
+	assertBool(t, errors.Is(err, strconv.ErrSyntax), true, "UnmarshalText sign")
+}
+
+func TestUint512_Append(t *testing.T) {
+	prefix := []byte("foo")
+	for i := 0; i < loopTimes/1000; i++ {
+		val := rand512()
+		bigVal := val.Big()
+
+		assertString(t, string(val.AppendDecimal(prefix)), "foo"+bigVal.String(), "AppendDecimal")
+		text, err := val.AppendText(prefix)
+		assertBool(t, err == nil, true, "AppendText error")
+		assertString(t, string(text), "foo"+val.String(), "AppendText")
+		text, err = val.MarshalText()
+		assertBool(t, err == nil, true, "MarshalText error")
+		assertString(t, string(text), val.String(), "MarshalText")
+
+		assertString(t, string(val.AppendHex(prefix, false)), fmt.Sprintf("foo%x", bigVal), "AppendHex")
+		assertString(t, string(val.AppendHex(prefix, true)), fmt.Sprintf("foo%0*x", 2*byteCount, bigVal), "AppendHex padded")
+
+		bin, err := val.AppendBinary(prefix)
+		assertBool(t, err == nil, true, "AppendBinary error")
+		assertString(t, fmt.Sprintf("%x", bin), fmt.Sprintf("%x%0*x", prefix, 2*byteCount, bigVal), "AppendBinary")
+	}
+
+	val := Max()
+	buf := make([]byte, 0, 1024)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = val.AppendText(buf[:0])
+		buf = val.AppendHex(buf[:0], true)
+		buf, _ = val.AppendBinary(buf[:0])
+	})
+	assertInt(t, int(allocs), 0, "Append allocs")
+}
+
+func TestUint512_JSON(t *testing.T) {
+	type Foo struct {
+		Str Uint512    `json:"str"`
+		Num JSONNumber `json:"num"`
+		Hex JSONHex    `json:"hex"`
+	}
+
+	for i := 0; i < loopTimes/1000; i++ {
+		val := rand512()
+		buf, err := json.Marshal(Foo{Str: val, Num: JSONNumber(val), Hex: JSONHex(val)})
+		assertBool(t, err == nil, true, "Marshal error")
+		assertString(t, string(buf), fmt.Sprintf(`{"str":"%d","num":%d,"hex":"%#x"}`, val.Big(), val.Big(), val.Big()), "Marshal")
+
+		var tmp Foo
+		err = json.Unmarshal(buf, &tmp)
+		assertBool(t, err == nil, true, "Unmarshal error")
+		assertBool(t, tmp.Str.Equals(val), true, "Unmarshal string")
+		assertBool(t, Uint512(tmp.Num).Equals(val), true, "Unmarshal number")
+		assertBool(t, Uint512(tmp.Hex).Equals(val), true, "Unmarshal hex")
+	}
+
+	var tmp Uint512
+	assertBool(t, tmp.UnmarshalJSON([]byte(Max().String()+"0")) != nil, true, "Unmarshal overflow")
+	assertBool(t, tmp.UnmarshalJSON([]byte(`"-1"`)) != nil, true, "Unmarshal negative")
+}
+
+func TestUint512_Binary(t *testing.T) {
+	for i := 0; i < loopTimes/1000; i++ {
+		val := rand512()
+		bigVal := val.Big()
+
+		buf, err := val.MarshalBinary()
+		assertBool(t, err == nil, true, "MarshalBinary error")
+		assertString(t, fmt.Sprintf("%x", buf), fmt.Sprintf("%0*x", 2*byteCount, bigVal), "MarshalBinary")
+
+		var u Uint512
+		assertBool(t, u.UnmarshalBinary(buf) == nil && u.Equals(val), true, "UnmarshalBinary")
+		assertBool(t, u.UnmarshalBinary(buf[1:]) != nil, true, "UnmarshalBinary short")
+
+		assertString(t, fmt.Sprintf("%x", val.Bytes()), fmt.Sprintf("%x", bigVal.Bytes()), "Bytes")
+		u, ok := FromBytes(val.Bytes())
+		assertBool(t, ok && u.Equals(val), true, "FromBytes")
+
+		var gobBuf bytes.Buffer
+		assertBool(t, gob.NewEncoder(&gobBuf).Encode(val) == nil, true, "GobEncode")
+		assertBool(t, gob.NewDecoder(&gobBuf).Decode(&u) == nil && u.Equals(val), true, "GobDecode")
+
+		assertBool(t, StoreLittleEndianChecked(buf, val) == nil, true, "StoreLittleEndianChecked")
+		u, err = LoadLittleEndianChecked(buf)
+		assertBool(t, err == nil && u.Equals(val), true, "LoadLittleEndianChecked")
+		assertBool(t, StoreBigEndianChecked(buf, val) == nil, true, "StoreBigEndianChecked")
+		u, err = LoadBigEndianChecked(buf)
+		assertBool(t, err == nil && u.Equals(val), true, "LoadBigEndianChecked")
+	}
+
+	u, ok := FromBytes(append([]byte{1}, make([]byte, byteCount)...))
+	assertBool(t, !ok && u.Equals(Max()), true, "FromBytes overflow")
+	assertBool(t, len(Zero().Bytes()) == 0, true, "Zero().Bytes()")
+
+	short := make([]byte, byteCount-1)
+	_, err := LoadLittleEndianChecked(short)
+	assertBool(t, errors.Is(err, io.ErrShortBuffer), true, "LoadLittleEndianChecked short")
+	_, err = LoadBigEndianChecked(short)
+	assertBool(t, errors.Is(err, io.ErrShortBuffer), true, "LoadBigEndianChecked short")
+	assertBool(t, errors.Is(StoreLittleEndianChecked(short, One()), io.ErrShortBuffer), true, "StoreLittleEndianChecked short")
+	assertBool(t, errors.Is(StoreBigEndianChecked(short, One()), io.ErrShortBuffer), true, "StoreBigEndianChecked short")
+}
+
+func TestUint512_Bytes(t *testing.T) {
+	for i := 0; i < loopTimes/1000; i++ {
+		val := rand512()
+		bigVal := val.Big()
+
+		for _, size := range []int{len(val.Bytes()), byteCount, byteCount + 5} {
+			got := val.FillBytes(bytes.Repeat([]byte{0xff}, size))
+			assertString(t, fmt.Sprintf("%x", got), fmt.Sprintf("%x", bigVal.FillBytes(make([]byte, size))), fmt.Sprintf("FillBytes-%d", size))
+		}
+
+		var u Uint512
+		assertBool(t, u.SetBytes(val.Bytes()) && u.Equals(val), true, "SetBytes")
+
+		var buf [byteCount]byte
+		StoreLittleEndianArray(&buf, val)
+		assertBool(t, LoadLittleEndianArray(&buf).Equals(val), true, "LoadLittleEndianArray")
+		StoreBigEndianArray(&buf, val)
+		assertBool(t, LoadBigEndianArray(&buf).Equals(val), true, "LoadBigEndianArray")
+		assertString(t, fmt.Sprintf("%x", buf), fmt.Sprintf("%0*x", 2*byteCount, bigVal), "StoreBigEndianArray")
+	}
+
+	var u Uint512
+	assertBool(t, !u.SetBytes(bytes.Repeat([]byte{1}, byteCount+1)) && u.Equals(Max()), true, "SetBytes overflow")
+
+	defer func() {
+		assertBool(t, recover() != nil, true, "FillBytes panic")
+	}()
+	Max().FillBytes(make([]byte, byteCount-1))
+}
+
+func TestUint512_Uvarint(t *testing.T) {
+	var stream bytes.Buffer
+	var values []Uint512
+	for i := 0; i < loopTimes/1000; i++ {
+		val := rand512().Rsh(uint(i % bitCount)) // more short values
+		enc := val.AppendUvarint(nil)
+		got, rest, err := DecodeUvarint(append(enc, 0x05))
+		assertBool(t, err == nil && bytes.Equal(rest, []byte{0x05}) && got.Equals(val), true, "DecodeUvarint")
+		stream.Write(enc)
+		values = append(values, val)
+	}
+
+	r := bytes.NewReader(stream.Bytes())
+	for _, val := range values {
+		got, err := ReadUvarint(r)
+		assertBool(t, err == nil && got.Equals(val), true, "ReadUvarint")
+	}
+	_, err := ReadUvarint(r)
+	assertBool(t, err == io.EOF, true, "ReadUvarint EOF")
+
+	assertInt(t, len(Max().AppendUvarint(nil)), MaxVarintLen, "MaxVarintLen")
+	assertString(t, fmt.Sprintf("%x", From64(300).AppendUvarint(nil)), "ac02", "AppendUvarint")
+	for _, b := range [][]byte{
+		{},
+		{0x80},
+		{0x80, 0x00},
+		append(bytes.Repeat([]byte{0xff}, MaxVarintLen-1), 0x7f),
+		append(bytes.Repeat([]byte{0xff}, MaxVarintLen), 0x01),
+	} {
+		_, _, err := DecodeUvarint(b)
+		assertBool(t, err != nil, true, fmt.Sprintf("DecodeUvarint(%x)", b))
+	}
+}
+
+func TestUint512_CBOR(t *testing.T) {
+	for i := 0; i < loopTimes/1000; i++ {
+		val := rand512().Rsh(uint(i % bitCount)) // more short values
+		enc, err := val.MarshalCBOR()
+		assertBool(t, err == nil, true, "MarshalCBOR")
+		if b := val.Big(); !b.IsUint64() {
+			assertString(t, fmt.Sprintf("%x", enc[len(enc)-len(b.Bytes()):]), fmt.Sprintf("%x", b.Bytes()), "MarshalCBOR bignum")
+			assertInt(t, int(enc[0]), 0xc2, "MarshalCBOR tag")
+		}
+
+		var u Uint512
+		assertBool(t, u.UnmarshalCBOR(enc) == nil && u.Equals(val), true, "UnmarshalCBOR")
+		got, rest, err := DecodeCBOR(append(enc, 0x05))
+		assertBool(t, err == nil && got.Equals(val) && bytes.Equal(rest, []byte{0x05}), true, "DecodeCBOR")
+	}
+
+	enc, _ := From64(1000).MarshalCBOR()
+	assertString(t, fmt.Sprintf("%x", enc), "1903e8", "MarshalCBOR")
+
+	var u Uint512
+	for _, b := range [][]byte{
+		{},
+		{0x20},
+		{0x00, 0x00},
+		append([]byte{0xc2, 0x58, byteCount + 1, 0x01}, make([]byte, byteCount)...),
+	} {
+		assertBool(t, u.UnmarshalCBOR(b) != nil, true, fmt.Sprintf("UnmarshalCBOR(%x)", b))
+	}
+}
+
+func TestUint512_DER(t *testing.T) {
+	for i := 0; i < loopTimes/1000; i++ {
+		val := rand512().Rsh(uint(i % bitCount)) // more short values
+		enc, err := val.MarshalDER()
+		expected, _ := asn1.Marshal(val.Big())
+		assertBool(t, err == nil, true, "MarshalDER")
+		assertString(t, fmt.Sprintf("%x", enc), fmt.Sprintf("%x", expected), "MarshalDER")
+
+		var u Uint512
+		assertBool(t, u.UnmarshalDER(enc) == nil && u.Equals(val), true, "UnmarshalDER")
+		got, rest, err := DecodeDER(append(enc, 0x05))
+		assertBool(t, err == nil && got.Equals(val) && bytes.Equal(rest, []byte{0x05}), true, "DecodeDER")
+
+		var raw asn1.RawValue
+		_, err = asn1.Unmarshal(enc, &raw)
+		assertBool(t, err == nil, true, "asn1.Unmarshal")
+		assertString(t, fmt.Sprintf("%x", val.RawValue().Bytes), fmt.Sprintf("%x", raw.Bytes), "RawValue")
+		got, err = FromRawValue(raw)
+		assertBool(t, err == nil && got.Equals(val), true, "FromRawValue")
+	}
+
+	var u Uint512
+	for _, b := range [][]byte{
+		{},
+		{0x02, 0x01, 0xff},
+		{0x02, 0x02, 0x00, 0x01},
+		append([]byte{0x02, byteCount + 1, 0x01}, make([]byte, byteCount)...),
+	} {
+		assertBool(t, u.UnmarshalDER(b) != nil, true, fmt.Sprintf("UnmarshalDER(%x)", b))
+	}
+}
+
+func TestUint512_SQL(t *testing.T) {
+	for i := 0; i < loopTimes/1000; i++ {
+		val := rand512()
+
+		v, err := val.Value()
+		assertBool(t, err == nil && v == val.String(), true, "Value")
+		var u Numeric
+		assertBool(t, u.Scan(v) == nil && Uint512(u).Equals(val), true, "Numeric.Scan")
+		assertBool(t, u.Scan([]byte(val.String()+".000")) == nil && Uint512(u).Equals(val), true, "Numeric.Scan bytes")
+
+		v, err = FixedBytes(val).Value()
+		assertBool(t, err == nil, true, "FixedBytes.Value")
+		var f FixedBytes
+		assertBool(t, f.Scan(v) == nil && Uint512(f).Equals(val), true, "FixedBytes.Scan")
+	}
+
+	var u Numeric
+	assertBool(t, u.Scan(int64(123)) == nil && Uint512(u).Equals(From64(123)), true, "Numeric.Scan int64")
+	for _, src := range []interface{}{nil, int64(-1), "-1", "1.5", Max().String() + "0"} {
+		assertBool(t, u.Scan(src) != nil, true, fmt.Sprintf("Numeric.Scan(%#v)", src))
+	}
+	var f FixedBytes
+	for _, src := range []interface{}{nil, "1", make([]byte, byteCount-1)} {
+		assertBool(t, f.Scan(src) != nil, true, fmt.Sprintf("FixedBytes.Scan(%#v)", src))
+	}
+}
+
+func TestUint512_Base(t *testing.T) {
+	for _, a := range []*alphabet.Alphabet{alphabet.Base58, alphabet.Crockford32, alphabet.Base36, alphabet.Base62} {
+		padLen := len(EncodeBase(Max(), a))
+		for i := 0; i < loopTimes/1000; i++ {
+			val := rand512().Rsh(uint(i % bitCount))
+			s := EncodeBasePadded(val, a)
+			assertInt(t, len(s), padLen, "EncodeBasePadded length")
+			got, err := DecodeBase(s, a)
+			assertBool(t, err == nil && got.Equals(val), true, "DecodeBase padded")
+			got, err = DecodeBase(EncodeBase(val, a), a)
+			assertBool(t, err == nil && got.Equals(val), true, "DecodeBase")
+		}
+
+		_, err := DecodeBase(EncodeBasePadded(Max(), a)+"1", a)
+		assertBool(t, errors.Is(err, strconv.ErrSyntax), true, "DecodeBase too long")
+		_, err = DecodeBase(strings.Repeat(a.String()[a.Radix()-1:], padLen), a)
+		assertBool(t, errors.Is(err, strconv.ErrRange), true, "DecodeBase overflow")
+	}
+	assertString(t, EncodeBase(From64(58), alphabet.Base58), "21", "EncodeBase")
+}
+
+func TestUint512_Human(t *testing.T) {
+	for i := 0; i < loopTimes/1000; i++ {
+		val := rand512().Rsh(uint(i % bitCount))
+		for _, s := range []string{FormatSI(val, -1), FormatIEC(val, -1), FormatSci(val, -1), FormatGrouped(val, "", 3)} {
+			got, err := ParseHuman(s)
+			assertBool(t, err == nil && got.Equals(val), true, "ParseHuman "+s)
+		}
+		assertString(t, strings.ReplaceAll(FormatGrouped(val, ",", 3), ",", ""), val.String(), "FormatGrouped")
+	}
+
+	assertString(t, FormatGrouped(From64(1234567), ",", 3), "1,234,567", "FormatGrouped")
+	assertString(t, FormatSI(From64(1234567), 2), "1.23M", "FormatSI")
+	assertString(t, FormatIEC(From64(1536), 1), "1.5Ki", "FormatIEC")
+	assertString(t, FormatSci(From64(12345), 2), "1.23e+04", "FormatSci")
+	assertString(t, FormatSci(Max(), 4), new(big.Float).SetInt(Max().Big()).Text('e', 4), "FormatSci Max")
+
+	got, err := ParseHuman("1.5M")
+	assertBool(t, err == nil && got.Equals(From64(1500000)), true, "ParseHuman 1.5M")
+	_, err = ParseHuman("1.2345k")
+	assertBool(t, err != nil, true, "ParseHuman fraction")
+	_, err = ParseHuman(Max().String() + "k")
+	assertBool(t, errors.Is(err, strconv.ErrRange), true, "ParseHuman overflow")
+}
+
+func TestUint512_OrderedKey(t *testing.T) {
+	prev := Zero()
+	prevAsc, prevDesc := prev.AppendOrderedKey(nil), prev.AppendOrderedKeyDesc(nil)
+	for i := 0; i < loopTimes/1000; i++ {
+		val := rand512().Rsh(uint(i % bitCount))
+		asc, desc := val.AppendOrderedKey(nil), val.AppendOrderedKeyDesc(nil)
+		assertInt(t, bytes.Compare(asc, prevAsc), val.Cmp(prev), "AppendOrderedKey order")
+		assertInt(t, bytes.Compare(desc, prevDesc), prev.Cmp(val), "AppendOrderedKeyDesc order")
+
+		got, rest, err := DecodeOrderedKey(append(asc, 0x05))
+		assertBool(t, err == nil && got.Equals(val) && bytes.Equal(rest, []byte{0x05}), true, "DecodeOrderedKey")
+		got, _, err = DecodeOrderedKeyDesc(desc)
+		assertBool(t, err == nil && got.Equals(val), true, "DecodeOrderedKeyDesc")
+		prev, prevAsc, prevDesc = val, asc, desc
+	}
+
+	assertInt(t, len(Max().AppendOrderedKey(nil)), byteCount+1, "AppendOrderedKey Max length")
+	_, _, err := DecodeOrderedKey(append([]byte{byteCount + 1, 1}, make([]byte, byteCount)...))
+	assertBool(t, err != nil, true, "DecodeOrderedKey overflow")
+	_, _, err = DecodeOrderedKey([]byte{2, 0, 1})
+	assertBool(t, err != nil, true, "DecodeOrderedKey non-canonical")
+}
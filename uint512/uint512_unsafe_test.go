@@ -0,0 +1,50 @@
+//go:build !purego && (386 || amd64 || arm || arm64 || loong64 || mips64le || mipsle || ppc64le || riscv64 || wasm)
+
+package uint512
+
+import (
+	"encoding/binary"
+	"testing"
+	"unsafe"
+)
+
+// TestLayout checks the memory layout assumptions
+// the unsafe littleEndianBytes implementation relies on.
+// Any change of Uint512 structure should fail here loudly.
+func TestLayout(t *testing.T) {
+	var u Uint512
+	if got := unsafe.Sizeof(u); got != byteCount {
+		t.Fatalf("Uint512 size should be %d bytes, got %d", byteCount, got)
+	}
+
+	// offsets are relative to the enclosing half
+	offsets := []struct {
+		field    string
+		got      uintptr
+		expected uintptr
+	}{
+		{"Lo", unsafe.Offsetof(u.Lo), 0},
+		{"Hi", unsafe.Offsetof(u.Hi), 32},
+		{"Lo.Hi", unsafe.Offsetof(u.Lo.Hi), 16},
+		{"Hi.Hi", unsafe.Offsetof(u.Hi.Hi), 16},
+		{"Lo.Lo.Hi", unsafe.Offsetof(u.Lo.Lo.Hi), 8},
+		{"Hi.Hi.Hi", unsafe.Offsetof(u.Hi.Hi.Hi), 8},
+	}
+	for _, o := range offsets {
+		if o.got != o.expected {
+			t.Fatalf("Uint512.%s offset should be %d, got %d", o.field, o.expected, o.got)
+		}
+	}
+
+	// each limb should go to its own place
+	for i := 0; i < uint64Count; i++ {
+		var w [uint64Count]uint64
+		w[i] = 0x0102030405060708 + uint64(i)
+		buf := FromWords(w).littleEndianBytes()
+		for j := 0; j < uint64Count; j++ {
+			if got := binary.LittleEndian.Uint64(buf[8*j:]); got != w[j] {
+				t.Fatalf("limb #%d: bytes [%d:%d] should be %#x, got %#x", i, 8*j, 8*j+8, w[j], got)
+			}
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package bigz
+
+import (
+	"math/big"
+
+	u512 "github.com/piliming/bigz/uint512"
+)
+
+// Uint512 is type alias for 512-bit unsigned integer.
+type Uint512 = u512.Uint512
+
+// Note, there in no New(lo, hi) just not to confuse
+// which half goes first: lower or upper.
+// Use structure initialization Uint512{Lo: ..., Hi: ...} instead.
+
+// Zero512 is the lowest possible Uint512 value.
+func Zero512() Uint512 {
+	return u512.Zero()
+}
+
+// One512 is the lowest non-zero Uint512 value.
+func One512() Uint512 {
+	return u512.One()
+}
+
+// Max512 is the largest possible Uint512 value.
+func Max512() Uint512 {
+	return u512.Max()
+}
+
+// From64To512 converts 64-bit value v to a Uint512 value.
+func From64To512(v uint64) Uint512 {
+	return u512.From64(v)
+}
+
+// FromBig512 converts *big.Int to Uint512 value with saturation.
+// See FromBigEx512 to detect overflows.
+func FromBig512(i *big.Int) Uint512 {
+	return u512.FromBig(i)
+}
+
+// FromBigEx512 converts *big.Int to Uint512 value (eXtended version).
+// Provides ok successful flag as a second return value.
+func FromBigEx512(i *big.Int) (Uint512, bool) {
+	return u512.FromBigEx(i)
+}
+
+// Parse512 parses input string as a Uint512 value.
+func Parse512(s string) (Uint512, error) {
+	return u512.FromString(s)
+}
@@ -0,0 +1,91 @@
+package bigz
+
+import (
+	"math/big"
+	"slices"
+)
+
+// Unsigned is the set of methods shared by all fixed-width unsigned integers:
+// Uint128, Uint256, Uint512 and Uint1024. It is used as a type constraint
+// in generic algorithms like Min, Max, Sum, GCD or Sort.
+//
+// Note, zero value of any type satisfying Unsigned is expected to be 0.
+type Unsigned[T any] interface {
+	Add(v T) T
+	Sub(v T) T
+	Mul(v T) T
+	QuoRem(v T) (T, T)
+	Cmp(v T) int
+	Lsh(n uint) T
+	Rsh(n uint) T
+	BitLen() int
+	IsZero() bool
+	String() string
+	Big() *big.Int
+}
+
+// ensure all types satisfy Unsigned
+var (
+	_ Unsigned[Uint128]  = Uint128{}
+	_ Unsigned[Uint256]  = Uint256{}
+	_ Unsigned[Uint512]  = Uint512{}
+	_ Unsigned[Uint1024] = Uint1024{}
+)
+
+// Min returns the smallest of provided values.
+// The result is zero if no values provided.
+func Min[T Unsigned[T]](values ...T) T {
+	var m T
+	for i, v := range values {
+		if i == 0 || v.Cmp(m) < 0 {
+			m = v
+		}
+	}
+	return m
+}
+
+// Max returns the largest of provided values.
+// The result is zero if no values provided.
+func Max[T Unsigned[T]](values ...T) T {
+	var m T
+	for i, v := range values {
+		if i == 0 || v.Cmp(m) > 0 {
+			m = v
+		}
+	}
+	return m
+}
+
+// Sum returns sum of provided values.
+// Wrap-around semantic is used here, just like for Add method.
+func Sum[T Unsigned[T]](values ...T) T {
+	var s T
+	for _, v := range values {
+		s = s.Add(v)
+	}
+	return s
+}
+
+// GCD returns the greatest common divisor of x and y.
+// GCD(x, 0) == GCD(0, x) == x.
+func GCD[T Unsigned[T]](x, y T) T {
+	for !y.IsZero() {
+		_, r := x.QuoRem(y)
+		x, y = y, r
+	}
+	return x
+}
+
+// Sort sorts values in increasing order.
+func Sort[T Unsigned[T]](values []T) {
+	slices.SortFunc(values, func(x, y T) int {
+		return x.Cmp(y)
+	})
+}
+
+// IsSorted reports whether values are sorted in increasing order.
+func IsSorted[T Unsigned[T]](values []T) bool {
+	return slices.IsSortedFunc(values, func(x, y T) int {
+		return x.Cmp(y)
+	})
+}
@@ -0,0 +1,69 @@
+package bigz_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/piliming/bigz"
+	"github.com/piliming/bigz/uint1024"
+	"github.com/piliming/bigz/uint128"
+	"github.com/piliming/bigz/uint256"
+	"github.com/piliming/bigz/uint512"
+)
+
+// testUnsigned checks generic helpers on values from 1 to 10 (shuffled).
+func testUnsigned[T bigz.Unsigned[T]](t *testing.T, from64 func(uint64) T) {
+	t.Helper()
+
+	values := []T{}
+	for _, v := range []uint64{7, 3, 10, 1, 9, 2, 8, 5, 4, 6} {
+		values = append(values, from64(v))
+	}
+
+	if got := bigz.Min(values...).String(); got != "1" {
+		t.Errorf("Min failed: %v", got)
+	}
+	if got := bigz.Max(values...).String(); got != "10" {
+		t.Errorf("Max failed: %v", got)
+	}
+	if got := bigz.Min[T]().String(); got != "0" {
+		t.Errorf("Min() failed: %v", got)
+	}
+	if got := bigz.Sum(values...).String(); got != "55" {
+		t.Errorf("Sum failed: %v", got)
+	}
+
+	x := from64(2 * 3 * 5 * 7).Lsh(100)
+	y := from64(3 * 7 * 11).Lsh(90)
+	expected := new(big.Int).GCD(nil, nil, x.Big(), y.Big())
+	if got := bigz.GCD(x, y); got.Big().Cmp(expected) != 0 {
+		t.Errorf("GCD(%v, %v) should be %v, got %v", x, y, expected, got)
+	}
+	if got := bigz.GCD(x, from64(0)); got.Cmp(x) != 0 {
+		t.Errorf("GCD(%v, 0) should be %v, got %v", x, x, got)
+	}
+	if got := bigz.GCD(from64(0), y); got.Cmp(y) != 0 {
+		t.Errorf("GCD(0, %v) should be %v, got %v", y, y, got)
+	}
+
+	if bigz.IsSorted(values) {
+		t.Errorf("IsSorted should fail on %v", values)
+	}
+	bigz.Sort(values)
+	if !bigz.IsSorted(values) {
+		t.Errorf("Sort failed: %v", values)
+	}
+	for i, v := range values {
+		if v.Cmp(from64(uint64(i+1))) != 0 {
+			t.Fatalf("Sort failed: %v", values)
+		}
+	}
+}
+
+// TestUnsigned tests generic helpers on all widths.
+func TestUnsigned(t *testing.T) {
+	t.Run("128", func(t *testing.T) { testUnsigned(t, uint128.From64) })
+	t.Run("256", func(t *testing.T) { testUnsigned(t, uint256.From64) })
+	t.Run("512", func(t *testing.T) { testUnsigned(t, uint512.From64) })
+	t.Run("1024", func(t *testing.T) { testUnsigned(t, uint1024.From64) })
+}